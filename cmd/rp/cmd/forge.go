@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/forge/forgejo"
+	"github.com/apricote/releaser-pleaser/internal/forge/github"
+	"github.com/apricote/releaser-pleaser/internal/forge/gitlab"
+	"github.com/apricote/releaser-pleaser/internal/forge/plaingit"
+)
+
+// forgeFlags select and configure the forge. They are shared by all commands that talk to a forge.
+type forgeFlags struct {
+	forge  string
+	branch string
+	owner  string
+	repo   string
+
+	apiURL   string
+	apiToken string
+	username string
+
+	remoteURL string
+	gitMode   string
+}
+
+func (f *forgeFlags) register(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&f.forge, "forge", "", "")
+	cmd.PersistentFlags().StringVar(&f.branch, "branch", "main", "")
+	cmd.PersistentFlags().StringVar(&f.owner, "owner", "", "")
+	cmd.PersistentFlags().StringVar(&f.repo, "repo", "", "")
+
+	cmd.PersistentFlags().StringVar(&f.apiURL, "api-url", "", "")
+	cmd.PersistentFlags().StringVar(&f.apiToken, "api-token", "", "")
+	cmd.PersistentFlags().StringVar(&f.username, "username", "", "")
+
+	cmd.PersistentFlags().StringVar(&f.remoteURL, "remote-url", "", "URL of the git remote, only used with --forge=git")
+	cmd.PersistentFlags().StringVar(&f.gitMode, "git-mode", plaingit.ModeBranch, "Release mode with --forge=git: \"branch\" only pushes the release branch, \"tag\" pushes the release commit and tag to --branch")
+}
+
+func (f *forgeFlags) newForge(ctx context.Context, logger *slog.Logger) (forge.Forge, error) {
+	forgeOptions := forge.Options{
+		Repository: f.repo,
+		BaseBranch: f.branch,
+	}
+
+	switch f.forge {
+	case "gitlab":
+		logger.DebugContext(ctx, "using forge GitLab")
+		gl, err := gitlab.New(logger, &gitlab.Options{
+			Options: forgeOptions,
+			Path:    fmt.Sprintf("%s/%s", f.owner, f.repo),
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to create client", "err", err)
+			return nil, fmt.Errorf("failed to create gitlab client: %w", err)
+		}
+		return gl, nil
+	case "github":
+		logger.DebugContext(ctx, "using forge GitHub")
+		return github.New(logger, &github.Options{
+			Options: forgeOptions,
+			Owner:   f.owner,
+			Repo:    f.repo,
+		}), nil
+	case "forgejo":
+		logger.DebugContext(ctx, "using forge Forgejo")
+		fj, err := forgejo.New(logger, &forgejo.Options{
+			Options: forgeOptions,
+			Owner:   f.owner,
+			Repo:    f.repo,
+
+			APIURL:   f.apiURL,
+			APIToken: f.apiToken,
+			Username: f.username,
+		})
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create client", "err", err)
+			return nil, fmt.Errorf("failed to create forgejo client: %w", err)
+		}
+		return fj, nil
+	case "git":
+		logger.DebugContext(ctx, "using forge plain git")
+		pg, err := plaingit.New(logger, &plaingit.Options{
+			Options: forgeOptions,
+			URL:     f.remoteURL,
+			Mode:    f.gitMode,
+
+			APIToken: f.apiToken,
+			Username: f.username,
+		})
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create client", "err", err)
+			return nil, fmt.Errorf("failed to create git client: %w", err)
+		}
+		return pg, nil
+	default:
+		return nil, fmt.Errorf("unknown --forge: %s", f.forge)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	rp "github.com/apricote/releaser-pleaser"
+)
+
+const (
+	// EnvGitHubOutput is the file that GitHub Actions and Forgejo Actions read the outputs of a step from.
+	EnvGitHubOutput = "GITHUB_OUTPUT"
+
+	// dotenvPrefix is added to all variables in the dotenv file to avoid collisions with other variables of the job.
+	dotenvPrefix = "RP_"
+)
+
+// output is a single value of the run result for CI systems. The key is prefixed with the name of the component for
+// all components but the root component.
+type output struct {
+	component string
+	key       string
+	value     string
+}
+
+// resultOutputs flattens the result of `rp run` into outputs. If multiple releases of a component were created, the
+// values of the last release are used.
+func resultOutputs(result *rp.RunResult) []output {
+	var outputs []output
+	set := func(component, key, value string) {
+		i := slices.IndexFunc(outputs, func(o output) bool { return o.component == component && o.key == key })
+		if i >= 0 {
+			outputs[i].value = value
+			return
+		}
+		outputs = append(outputs, output{component: component, key: key, value: value})
+	}
+
+	set("", "changed", strconv.FormatBool(result.Changed))
+	set("", "dry-run", strconv.FormatBool(result.DryRun))
+	set("", "releases-created", strconv.FormatBool(len(result.Releases) > 0))
+
+	for _, release := range result.Releases {
+		set(release.Component, "release-created", "true")
+		set(release.Component, "version", release.Version)
+		set(release.Component, "tag", release.Tag)
+		set(release.Component, "commit", release.Commit)
+		set(release.Component, "release-url", release.URL)
+		set(release.Component, "prerelease", strconv.FormatBool(release.Prerelease))
+	}
+
+	for _, pr := range result.PullRequests {
+		set(pr.Component, "pr-id", strconv.FormatInt(pr.ID, 10))
+		set(pr.Component, "pr-url", pr.URL)
+		set(pr.Component, "pr-version", pr.Version)
+		set(pr.Component, "pr-tag", pr.Tag)
+		set(pr.Component, "pr-status", pr.Status)
+	}
+
+	return outputs
+}
+
+// writeJSONResult writes the result as indented JSON to the file, or to stdout if the path is `-`.
+func writeJSONResult(result *rp.RunResult, path string, stdout io.Writer) error {
+	if path == "-" {
+		return encodeJSONResult(result, stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create result file: %w", err)
+	}
+	defer file.Close()
+
+	return encodeJSONResult(result, file)
+}
+
+func encodeJSONResult(result *rp.RunResult, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+
+	return nil
+}
+
+// writeGitHubOutput appends the outputs to the file in the format of GitHub Actions. Keys of components are prefixed
+// with `<component>--`. The complete result is available as JSON in the output `result`.
+func writeGitHubOutput(result *rp.RunResult, path string) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	for _, o := range resultOutputs(result) {
+		key := o.key
+		if o.component != "" {
+			key = o.component + "--" + key
+		}
+
+		_, _ = fmt.Fprintf(&content, "%s=%s\n", key, o.value)
+	}
+	_, _ = fmt.Fprintf(&content, "result=%s\n", resultJSON)
+
+	return appendFile(path, content.String())
+}
+
+// writeDotenv writes the outputs as a dotenv file, eg. for the dotenv report of GitLab CI/CD. The variables are named
+// `RP_<COMPONENT>_<KEY>` in upper case, eg. `RP_API_RELEASE_URL`. Variables of the root component have no component
+// part.
+func writeDotenv(result *rp.RunResult, path string) error {
+	var content strings.Builder
+	for _, o := range resultOutputs(result) {
+		key := o.key
+		if o.component != "" {
+			key = o.component + "_" + key
+		}
+
+		_, _ = fmt.Fprintf(&content, "%s%s=%s\n", dotenvPrefix, dotenvKey(key), o.value)
+	}
+
+	if err := os.WriteFile(path, []byte(content.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write dotenv file: %w", err)
+	}
+
+	return nil
+}
+
+// dotenvKey converts the key to upper case and replaces all characters that are not allowed in variable names.
+func dotenvKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+func appendFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err = file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
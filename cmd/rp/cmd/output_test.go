@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rp "github.com/apricote/releaser-pleaser"
+)
+
+var testRunResult = &rp.RunResult{
+	Changed: true,
+	Releases: []rp.ReleaseResult{
+		{Version: "1.2.0", Tag: "v1.2.0", Commit: "abc", URL: "https://example.com/releases/v1.2.0", Latest: true},
+	},
+	PullRequests: []rp.PullRequestResult{
+		{Component: "api-server", ID: 3, URL: "https://example.com/pulls/3", Version: "2.0.0", Tag: "api-server/v2.0.0", Status: rp.PullRequestCreated},
+	},
+}
+
+func Test_writeJSONResult(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, writeJSONResult(&rp.RunResult{Releases: []rp.ReleaseResult{}, PullRequests: []rp.PullRequestResult{}}, "-", &stdout))
+	assert.Equal(t, `{
+  "changed": false,
+  "dry_run": false,
+  "releases": [],
+  "pull_requests": []
+}
+`, stdout.String())
+
+	path := filepath.Join(t.TempDir(), "result.json")
+	require.NoError(t, writeJSONResult(testRunResult, path, &stdout))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"tag": "api-server/v2.0.0"`)
+}
+
+func Test_writeGitHubOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github_output")
+	require.NoError(t, os.WriteFile(path, []byte("existing=true\n"), 0o644))
+
+	require.NoError(t, writeGitHubOutput(testRunResult, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `existing=true
+changed=true
+dry-run=false
+releases-created=true
+release-created=true
+version=1.2.0
+tag=v1.2.0
+commit=abc
+release-url=https://example.com/releases/v1.2.0
+prerelease=false
+api-server--pr-id=3
+api-server--pr-url=https://example.com/pulls/3
+api-server--pr-version=2.0.0
+api-server--pr-tag=api-server/v2.0.0
+api-server--pr-status=created
+result={"changed":true,"dry_run":false,"releases":[{"component":"","version":"1.2.0","tag":"v1.2.0","commit":"abc","url":"https://example.com/releases/v1.2.0","prerelease":false,"latest":true}],"pull_requests":[{"component":"api-server","id":3,"url":"https://example.com/pulls/3","version":"2.0.0","tag":"api-server/v2.0.0","status":"created"}]}
+`, string(content))
+}
+
+func Test_writeDotenv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "releaser-pleaser.env")
+
+	require.NoError(t, writeDotenv(testRunResult, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `RP_CHANGED=true
+RP_DRY_RUN=false
+RP_RELEASES_CREATED=true
+RP_RELEASE_CREATED=true
+RP_VERSION=1.2.0
+RP_TAG=v1.2.0
+RP_COMMIT=abc
+RP_RELEASE_URL=https://example.com/releases/v1.2.0
+RP_PRERELEASE=false
+RP_API_SERVER_PR_ID=3
+RP_API_SERVER_PR_URL=https://example.com/pulls/3
+RP_API_SERVER_PR_VERSION=2.0.0
+RP_API_SERVER_PR_TAG=api-server/v2.0.0
+RP_API_SERVER_PR_STATUS=created
+`, string(content))
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	rp "github.com/apricote/releaser-pleaser"
+	"github.com/apricote/releaser-pleaser/internal/commitparser/conventionalcommits"
+	"github.com/apricote/releaser-pleaser/internal/config"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/log"
+)
+
+func newPreviewCommand() *cobra.Command {
+	var (
+		flagPath       string
+		flagVersioning string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Show the next version and changelog of the local repository",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := log.GetLogger(cmd.ErrOrStderr())
+
+			logger.DebugContext(ctx, "preview called", "path", flagPath)
+
+			repo, err := git.OpenRepo(logger, flagPath)
+			if err != nil {
+				return err
+			}
+
+			cfg, cfgFile, err := config.Load(ctx, repo)
+			if err != nil {
+				return err
+			}
+			if cfgFile != "" {
+				logger.InfoContext(ctx, "loaded configuration file", "file", cfgFile)
+			}
+
+			versioning := cfg.Versioning
+			if flagVersioning != "" {
+				versioning = flagVersioning
+			}
+			versioningStrategy, err := parseVersioning(versioning, cfg.PreMajorBumps)
+			if err != nil {
+				return err
+			}
+
+			components, err := parseComponents(cfg, versioning, "", []string{}, nil)
+			if err != nil {
+				return err
+			}
+
+			commitTypes := parseCommitTypes(cfg.CommitTypes)
+
+			templates, err := loadTemplates(ctx, repo, cfg.Templates)
+			if err != nil {
+				return err
+			}
+
+			results, err := rp.Preview(ctx, logger, repo, conventionalcommits.NewParser(logger, commitTypes), commitTypes, versioningStrategy, components, templates)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, result := range results {
+				name := result.Component.Name
+				if name == "" {
+					name = "repository"
+				}
+
+				if result.Version == "" {
+					_, err = fmt.Fprintf(out, "No releasable commits for %s\n\n", name)
+				} else {
+					_, err = fmt.Fprintf(out, "Next version for %s: %s\n\n%s\n", name, result.Tag, result.Changelog)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&flagPath, "path", ".", "Path of the local git repository")
+	cmd.PersistentFlags().StringVar(&flagVersioning, "versioning", "", `Versioning strategy: "semver" (default), "calver" or "calver:<format>"`)
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPreviewRepo creates a repository in a temporary directory with one commit per message. The first commit is
+// tagged with tag, if it is not empty.
+func newPreviewRepo(t *testing.T, files map[string]string, tag string, messages ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o644))
+		_, err = worktree.Add(path)
+		require.NoError(t, err)
+	}
+
+	for i, message := range messages {
+		hash, err := worktree.Commit(message, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author:            &object.Signature{Name: "releaser-pleaser", When: time.Date(2020, 1, 1, 0, i, 0, 0, time.UTC)},
+		})
+		require.NoError(t, err)
+
+		if i == 0 && tag != "" {
+			_, err = repo.CreateTag(tag, hash, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	return dir
+}
+
+func TestPreviewCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		tag      string
+		messages []string
+		want     []string
+	}{
+		{
+			name:     "releasable commits",
+			tag:      "v1.0.0",
+			messages: []string{"chore: init", "feat: foo"},
+			want:     []string{"Next version for repository: v1.1.0", "### Features", "foo"},
+		},
+		{
+			name:     "no releasable commits",
+			tag:      "v1.0.0",
+			messages: []string{"chore: init", "chore: update dependencies"},
+			want:     []string{"No releasable commits for repository"},
+		},
+		{
+			name: "components from configuration file",
+			files: map[string]string{
+				".releaser-pleaser.yaml": "components:\n  - name: api\n    path: .\n  - name: web\n    path: web\n",
+			},
+			messages: []string{"feat: foo"},
+			want:     []string{"Next version for api: api/v0.1.0", "No releasable commits for web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newPreviewRepo(t, tt.files, tt.tag, tt.messages...)
+
+			var stdout bytes.Buffer
+			cmd := NewRootCmd()
+			cmd.SetArgs([]string{"preview", "--path", dir})
+			cmd.SetOut(&stdout)
+			cmd.SetErr(io.Discard)
+			require.NoError(t, cmd.Execute())
+
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
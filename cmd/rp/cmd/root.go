@@ -22,6 +22,8 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newRunCommand())
+	cmd.AddCommand(newPreviewCommand())
+	cmd.AddCommand(newUploadAssetsCommand())
 
 	return cmd
 }
@@ -1,36 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log/slog"
+	"io"
+	"os"
 	"slices"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
 	rp "github.com/apricote/releaser-pleaser"
+	"github.com/apricote/releaser-pleaser/internal/changelog"
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
 	"github.com/apricote/releaser-pleaser/internal/commitparser/conventionalcommits"
-	"github.com/apricote/releaser-pleaser/internal/forge"
-	"github.com/apricote/releaser-pleaser/internal/forge/forgejo"
-	"github.com/apricote/releaser-pleaser/internal/forge/github"
-	"github.com/apricote/releaser-pleaser/internal/forge/gitlab"
+	"github.com/apricote/releaser-pleaser/internal/config"
+	"github.com/apricote/releaser-pleaser/internal/forge/dryrun"
 	"github.com/apricote/releaser-pleaser/internal/log"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
 	"github.com/apricote/releaser-pleaser/internal/updater"
 	"github.com/apricote/releaser-pleaser/internal/versioning"
 )
 
+const (
+	defaultLabelColor = "DEDEDE"
+)
+
 func newRunCommand() *cobra.Command {
 	var (
-		flagForge      string
-		flagBranch     string
-		flagOwner      string
-		flagRepo       string
+		flagForge      forgeFlags
 		flagExtraFiles string
 		flagUpdaters   []string
+		flagAssets     string
+		flagDryRun     bool
+		flagVersioning string
 
-		flagAPIURL   string
-		flagAPIToken string
-		flagUsername string
+		flagOutputJSON   string
+		flagOutputDotenv string
 	)
 
 	cmd := &cobra.Command{
@@ -39,105 +47,307 @@ func newRunCommand() *cobra.Command {
 			ctx := cmd.Context()
 			logger := log.GetLogger(cmd.ErrOrStderr())
 
-			var err error
-
 			logger.DebugContext(ctx, "run called",
-				"forge", flagForge,
-				"branch", flagBranch,
-				"owner", flagOwner,
-				"repo", flagRepo,
+				"forge", flagForge.forge,
+				"branch", flagForge.branch,
+				"owner", flagForge.owner,
+				"repo", flagForge.repo,
 			)
 
-			var f forge.Forge
+			f, err := flagForge.newForge(ctx, logger)
+			if err != nil {
+				return err
+			}
 
-			forgeOptions := forge.Options{
-				Repository: flagRepo,
-				BaseBranch: flagBranch,
+			cfg, cfgFile, err := config.Load(ctx, f)
+			if err != nil {
+				return err
+			}
+			if cfgFile != "" {
+				logger.InfoContext(ctx, "loaded configuration file", "file", cfgFile)
 			}
 
-			switch flagForge {
-			case "gitlab":
-				logger.DebugContext(ctx, "using forge GitLab")
-				f, err = gitlab.New(logger, &gitlab.Options{
-					Options: forgeOptions,
-					Path:    fmt.Sprintf("%s/%s", flagOwner, flagRepo),
-				})
-				if err != nil {
-					slog.ErrorContext(ctx, "failed to create client", "err", err)
-					return fmt.Errorf("failed to create gitlab client: %w", err)
-				}
-			case "github":
-				logger.DebugContext(ctx, "using forge GitHub")
-				f = github.New(logger, &github.Options{
-					Options: forgeOptions,
-					Owner:   flagOwner,
-					Repo:    flagRepo,
-				})
-			case "forgejo":
-				logger.DebugContext(ctx, "using forge Forgejo")
-				f, err = forgejo.New(logger, &forgejo.Options{
-					Options: forgeOptions,
-					Owner:   flagOwner,
-					Repo:    flagRepo,
-
-					APIURL:   flagAPIURL,
-					APIToken: flagAPIToken,
-					Username: flagUsername,
-				})
-				if err != nil {
-					logger.ErrorContext(ctx, "failed to create client", "err", err)
-					return fmt.Errorf("failed to create forgejo client: %w", err)
-				}
-			default:
-				return fmt.Errorf("unknown --forge: %s", flagForge)
+			if flagDryRun {
+				logger.InfoContext(ctx, "running in dry-run mode, no changes are made to the repository")
+				f = dryrun.New(logger, f)
 			}
 
-			extraFiles := parseExtraFiles(flagExtraFiles)
-
-			updaterNames := parseUpdaters(flagUpdaters)
-			updaters := []updater.Updater{}
-			for _, name := range updaterNames {
-				switch name {
-				case "generic":
-					updaters = append(updaters, updater.Generic(extraFiles))
-				case "changelog":
-					updaters = append(updaters, updater.Changelog())
-				case "packagejson":
-					updaters = append(updaters, updater.PackageJson())
-				default:
-					return fmt.Errorf("unknown updater: %s", name)
-				}
+			versioning := cfg.Versioning
+			if flagVersioning != "" {
+				versioning = flagVersioning
+			}
+			versioningStrategy, err := parseVersioning(versioning, cfg.PreMajorBumps)
+			if err != nil {
+				return err
+			}
+
+			components, err := parseComponents(cfg, versioning, flagExtraFiles, flagUpdaters, parseFileList(flagAssets))
+			if err != nil {
+				return err
+			}
+
+			commitTypes := parseCommitTypes(cfg.CommitTypes)
+
+			templates, err := loadTemplates(ctx, f, cfg.Templates)
+			if err != nil {
+				return err
 			}
 
 			releaserPleaser := rp.New(
 				f,
 				logger,
-				flagBranch,
-				conventionalcommits.NewParser(logger),
-				versioning.SemVer,
-				extraFiles,
-				updaters,
+				flagForge.branch,
+				conventionalcommits.NewParser(logger, commitTypes),
+				commitTypes,
+				versioningStrategy,
+				components,
+				parseLabels(cfg.Labels),
+				templates,
+				flagDryRun,
+				// stdout is reserved for the result with --output-json=-
+				cmd.ErrOrStderr(),
 			)
 
-			return releaserPleaser.Run(ctx)
+			result, err := releaserPleaser.Run(ctx)
+			if result != nil {
+				// The outputs are written even if the run failed, as releases might have been created before the error.
+				err = errors.Join(err, writeResult(result, flagOutputJSON, flagOutputDotenv, cmd.OutOrStdout()))
+			}
+
+			return err
 		},
 	}
 
-	cmd.PersistentFlags().StringVar(&flagForge, "forge", "", "")
-	cmd.PersistentFlags().StringVar(&flagBranch, "branch", "main", "")
-	cmd.PersistentFlags().StringVar(&flagOwner, "owner", "", "")
-	cmd.PersistentFlags().StringVar(&flagRepo, "repo", "", "")
+	flagForge.register(cmd)
 	cmd.PersistentFlags().StringVar(&flagExtraFiles, "extra-files", "", "")
 	cmd.PersistentFlags().StringSliceVar(&flagUpdaters, "updaters", []string{}, "")
+	cmd.PersistentFlags().StringVar(&flagAssets, "assets", "", "Newline-separated glob patterns of files that are attached to the created releases, replaces the assets of the configuration file")
+	cmd.PersistentFlags().BoolVar(&flagDryRun, "dry-run", false, "Log all changes instead of applying them to the repository")
+	cmd.PersistentFlags().StringVar(&flagVersioning, "versioning", "", `Versioning strategy: "semver" (default), "calver" or "calver:<format>"`)
 
-	cmd.PersistentFlags().StringVar(&flagAPIURL, "api-url", "", "")
-	cmd.PersistentFlags().StringVar(&flagAPIToken, "api-token", "", "")
-	cmd.PersistentFlags().StringVar(&flagUsername, "username", "", "")
+	cmd.PersistentFlags().StringVar(&flagOutputJSON, "output-json", "", `Write the result of the run as JSON to the file, "-" writes to stdout`)
+	cmd.PersistentFlags().StringVar(&flagOutputDotenv, "output-dotenv", "", "Write the result of the run as dotenv file, eg. for the dotenv report of GitLab CI/CD")
 
 	return cmd
 }
 
-func parseExtraFiles(input string) []string {
+// writeResult writes the result to the files passed in the flags. If the environment variable EnvGitHubOutput is set,
+// the outputs of the GitHub Action are written as well.
+func writeResult(result *rp.RunResult, jsonPath, dotenvPath string, stdout io.Writer) error {
+	if jsonPath != "" {
+		if err := writeJSONResult(result, jsonPath, stdout); err != nil {
+			return err
+		}
+	}
+
+	if dotenvPath != "" {
+		if err := writeDotenv(result, dotenvPath); err != nil {
+			return err
+		}
+	}
+
+	if githubOutput := os.Getenv(EnvGitHubOutput); githubOutput != "" {
+		if err := writeGitHubOutput(result, githubOutput); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseVersioning returns the strategy for the name. CalVer accepts an optional format after a colon, for example
+// `calver:YY.0M.MICRO`. preMajorBumps is only supported by SemVer.
+func parseVersioning(name string, preMajorBumps bool) (versioning.Strategy, error) {
+	name, format, _ := strings.Cut(name, ":")
+
+	switch name {
+	case "", "semver":
+		if preMajorBumps {
+			return versioning.SemVerPreMajor, nil
+		}
+		return versioning.SemVer, nil
+	case "calver":
+		if preMajorBumps {
+			return nil, fmt.Errorf("pre-major-bumps is not supported with versioning strategy calver")
+		}
+		return versioning.NewCalVer(format)
+	default:
+		return nil, fmt.Errorf("unknown versioning strategy: %s", name)
+	}
+}
+
+// defaultTagTemplate returns the tag template for the versioning strategy that is used if no template is configured.
+func defaultTagTemplate(name string) string {
+	if name, _, _ = strings.Cut(name, ":"); name == "calver" {
+		return versioning.DefaultCalVerTagTemplate
+	}
+
+	return versioning.DefaultSemVerTagTemplate
+}
+
+// parseComponents builds the components from the configuration file. If no components are configured, a single
+// component for the whole repository is returned. The flags take precedence over the configuration file and are
+// applied to every component. The assets of a component replace the top-level assets.
+//
+// Components without their own tag template use the global template. If it does not contain the name of the
+// component, the tags are prefixed with `<name>/`.
+func parseComponents(cfg *config.Config, versioningName string, flagExtraFiles string, flagUpdaters []string, flagAssets []string) ([]rp.Component, error) {
+	configComponents := cfg.Components
+	if len(configComponents) == 0 {
+		configComponents = []config.Component{{}}
+	}
+
+	globalTagTemplate := cfg.TagTemplate
+	if globalTagTemplate == "" {
+		globalTagTemplate = defaultTagTemplate(versioningName)
+	}
+
+	components := make([]rp.Component, 0, len(configComponents))
+	for _, c := range configComponents {
+		extraFiles := parseFileList(flagExtraFiles)
+		if len(extraFiles) == 0 {
+			extraFiles = slices.Concat(cfg.ExtraFiles, c.ExtraFiles)
+		}
+
+		assets := flagAssets
+		if len(assets) == 0 {
+			assets = c.Assets
+		}
+		if len(assets) == 0 {
+			assets = cfg.Assets
+		}
+
+		changelogFile := c.Changelog.File
+		if changelogFile == "" {
+			changelogFile = cfg.Changelog.File
+		}
+
+		helmCharts := c.Helm.Charts
+		if len(helmCharts) == 0 {
+			helmCharts = cfg.Helm.Charts
+		}
+
+		updaters, err := newUpdaters(parseUpdaters(slices.Concat(cfg.Updaters, c.Updaters, flagUpdaters)), updaterOptions{
+			extraFiles:    extraFiles,
+			changelogFile: changelogFile,
+			generic: config.Generic{
+				Scan:   cfg.Generic.Scan || c.Generic.Scan,
+				Ignore: slices.Concat(cfg.Generic.Ignore, c.Generic.Ignore),
+			},
+			helm: config.Helm{
+				Charts:     helmCharts,
+				AppVersion: cfg.Helm.AppVersion || c.Helm.AppVersion,
+			},
+			python: config.Python{
+				InitVersion: cfg.Python.InitVersion || c.Python.InitVersion,
+			},
+			jvm: config.JVM{
+				Snapshot: cfg.JVM.Snapshot || c.JVM.Snapshot,
+			},
+			paths:   slices.Concat(cfg.Paths, c.Paths),
+			regexes: slices.Concat(cfg.Regexes, c.Regexes),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tagTemplateText := c.TagTemplate
+		if tagTemplateText == "" {
+			tagTemplateText = globalTagTemplate
+			if c.Name != "" && !strings.Contains(tagTemplateText, ".Component") {
+				tagTemplateText = "{{.Component}}/" + tagTemplateText
+			}
+		}
+
+		tagTemplate, err := versioning.NewTagTemplate(tagTemplateText, c.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, rp.Component{
+			Name:        c.Name,
+			Path:        c.Path,
+			TagTemplate: tagTemplate,
+			Updaters:    updaters,
+			Assets:      assets,
+
+			BeforeCommitHooks: slices.Concat(cfg.Hooks.BeforeCommit, c.Hooks.BeforeCommit),
+			AfterReleaseHooks: slices.Concat(cfg.Hooks.AfterRelease, c.Hooks.AfterRelease),
+		})
+	}
+
+	return components, nil
+}
+
+// updaterOptions are the settings of the updaters that can be configured.
+type updaterOptions struct {
+	extraFiles    []string
+	changelogFile string
+	generic       config.Generic
+	helm          config.Helm
+	python        config.Python
+	jvm           config.JVM
+	paths         []config.Path
+	regexes       []config.Regex
+}
+
+func newUpdaters(names []string, options updaterOptions) ([]updater.Updater, error) {
+	updaters := []updater.Updater{}
+	for _, name := range names {
+		switch name {
+		case "generic":
+			if options.generic.Scan {
+				updaters = append(updaters, updater.GenericScan(options.extraFiles, options.generic.Ignore))
+			} else {
+				updaters = append(updaters, updater.Generic(options.extraFiles))
+			}
+		case "changelog":
+			if options.changelogFile != "" {
+				updaters = append(updaters, updater.ChangelogAt(options.changelogFile))
+			} else {
+				updaters = append(updaters, updater.Changelog())
+			}
+		case "packagejson":
+			updaters = append(updaters, updater.PackageJson())
+		case "cargo":
+			updaters = append(updaters, updater.Cargo())
+		case "pyproject":
+			updaters = append(updaters, updater.Pyproject(options.python.InitVersion))
+		case "helm":
+			updaters = append(updaters, updater.Helm(options.helm.Charts, options.helm.AppVersion))
+		case "maven":
+			updaters = append(updaters, updater.Maven(options.jvm.Snapshot))
+		case "gradle":
+			updaters = append(updaters, updater.Gradle(options.jvm.Snapshot))
+		case "path":
+			for _, p := range options.paths {
+				if p.File == "" || p.Path == "" {
+					return nil, fmt.Errorf("invalid path updater entry: file and path are required")
+				}
+				updaters = append(updaters, updater.Path(p.File, p.Path, p.Prefix))
+			}
+		case "regex":
+			for _, r := range options.regexes {
+				if len(r.Files) == 0 || r.Pattern == "" {
+					return nil, fmt.Errorf("invalid regex updater entry: files and pattern are required")
+				}
+				u, err := updater.Regex(r.Files, r.Pattern, r.Template)
+				if err != nil {
+					return nil, err
+				}
+				updaters = append(updaters, u)
+			}
+		default:
+			return nil, fmt.Errorf("unknown updater: %s", name)
+		}
+	}
+
+	return updaters, nil
+}
+
+// parseFileList splits the newline-separated list of files or glob patterns that is passed through the inputs of the
+// CI integrations.
+func parseFileList(input string) []string {
 	// We quote the arg to avoid issues with the expected newlines in the value.
 	// Need to remove those quotes before parsing the data
 	input = strings.Trim(input, `"`)
@@ -146,15 +356,15 @@ func parseExtraFiles(input string) []string {
 	input = strings.ReplaceAll(input, `\n`, "\n")
 	lines := strings.Split(input, "\n")
 
-	extraFiles := make([]string, 0, len(lines))
+	files := make([]string, 0, len(lines))
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		if len(line) > 0 {
-			extraFiles = append(extraFiles, line)
+			files = append(files, line)
 		}
 	}
 
-	return extraFiles
+	return files
 }
 
 func parseUpdaters(input []string) []string {
@@ -179,3 +389,84 @@ func parseUpdaters(input []string) []string {
 
 	return names
 }
+
+// loadTemplates reads and parses the configured template files from the repository.
+func loadTemplates(ctx context.Context, reader config.FileReader, cfg config.Templates) (changelog.Templates, error) {
+	templates := changelog.Templates{}
+
+	files := []struct {
+		path     string
+		template **template.Template
+	}{
+		{cfg.Changelog, &templates.Changelog},
+		{cfg.PullRequest, &templates.PullRequest},
+		{cfg.Release, &templates.Release},
+	}
+
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+
+		content, err := reader.ReadFile(ctx, file.path)
+		if err != nil {
+			return changelog.Templates{}, fmt.Errorf("failed to read template file %q: %w", file.path, err)
+		}
+		if content == nil {
+			return changelog.Templates{}, fmt.Errorf("template file %q does not exist", file.path)
+		}
+
+		*file.template, err = changelog.Parse(file.path, string(content))
+		if err != nil {
+			return changelog.Templates{}, err
+		}
+	}
+
+	return templates, nil
+}
+
+// parseCommitTypes returns the configured commit types, or commitparser.DefaultCommitTypes if none are configured.
+func parseCommitTypes(input []config.CommitType) commitparser.CommitTypes {
+	if len(input) == 0 {
+		return commitparser.DefaultCommitTypes
+	}
+
+	types := make(commitparser.CommitTypes, 0, len(input))
+	for _, t := range input {
+		commitType := commitparser.CommitType{
+			Type:    t.Type,
+			Section: t.Section,
+			Hidden:  t.Hidden,
+			Bump:    commitparser.Bump(t.Bump),
+		}
+		if commitType.Section == "" {
+			commitType.Section = commitType.Type
+		}
+		if commitType.Bump == "" {
+			commitType.Bump = commitparser.BumpNone
+		}
+
+		types = append(types, commitType)
+	}
+
+	return types
+}
+
+func parseLabels(input []config.Label) []releasepr.Label {
+	labels := make([]releasepr.Label, 0, len(input))
+
+	for _, l := range input {
+		label := releasepr.Label{
+			Name:        l.Name,
+			Color:       strings.TrimPrefix(l.Color, "#"),
+			Description: l.Description,
+		}
+		if label.Color == "" {
+			label.Color = defaultLabelColor
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels
+}
@@ -1,12 +1,25 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rp "github.com/apricote/releaser-pleaser"
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+	"github.com/apricote/releaser-pleaser/internal/config"
+	"github.com/apricote/releaser-pleaser/internal/forge/fake"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+	"github.com/apricote/releaser-pleaser/internal/updater"
 )
 
-func Test_parseExtraFiles(t *testing.T) {
+func Test_parseFileList(t *testing.T) {
 	tests := []struct {
 		name  string
 		input string
@@ -52,7 +65,7 @@ dir/Chart.yaml"`,
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := parseExtraFiles(tt.input)
+			got := parseFileList(tt.input)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -102,3 +115,398 @@ func Test_parseUpdaters(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []config.Label
+		want  []releasepr.Label
+	}{
+		{
+			name:  "empty",
+			input: []config.Label{},
+			want:  []releasepr.Label{},
+		},
+		{
+			name:  "default color",
+			input: []config.Label{{Name: "release"}},
+			want:  []releasepr.Label{{Name: "release", Color: "DEDEDE"}},
+		},
+		{
+			name:  "strip color hash",
+			input: []config.Label{{Name: "release", Color: "#00FF00", Description: "Release"}},
+			want:  []releasepr.Label{{Name: "release", Color: "00FF00", Description: "Release"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLabels(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseCommitTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []config.CommitType
+		want  commitparser.CommitTypes
+	}{
+		{
+			name:  "empty",
+			input: []config.CommitType{},
+			want:  commitparser.DefaultCommitTypes,
+		},
+		{
+			name:  "defaults",
+			input: []config.CommitType{{Type: "refactor"}},
+			want:  commitparser.CommitTypes{{Type: "refactor", Section: "refactor", Bump: commitparser.BumpNone}},
+		},
+		{
+			name: "full",
+			input: []config.CommitType{
+				{Type: "perf", Section: "Performance", Bump: "patch"},
+				{Type: "deps", Section: "Dependencies", Hidden: true, Bump: "minor"},
+			},
+			want: commitparser.CommitTypes{
+				{Type: "perf", Section: "Performance", Bump: commitparser.BumpPatch},
+				{Type: "deps", Section: "Dependencies", Hidden: true, Bump: commitparser.BumpMinor},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCommitTypes(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseComponents(t *testing.T) {
+	t.Run("no components", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Empty(t, got[0].Name)
+		assert.Len(t, got[0].Updaters, 2)
+	})
+
+	t.Run("multiple components", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic"},
+			Components: []config.Component{
+				{Name: "api", Path: "services/api", Updaters: []string{"packagejson"}},
+				{Name: "web", Path: "web", Changelog: config.Changelog{File: "CHANGES.md"}},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, "api", got[0].Name)
+		assert.Equal(t, "services/api", got[0].Path)
+		assert.Len(t, got[0].Updaters, 2)
+
+		assert.Equal(t, "web", got[1].Name)
+		require.Len(t, got[1].Updaters, 1)
+		assert.Equal(t, []string{"CHANGES.md"}, got[1].Updaters[0].Files())
+	})
+
+	t.Run("tag templates", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Components: []config.Component{
+				{Name: "api"},
+				{Name: "web", TagTemplate: "{{.Component}}@{{.Version}}"},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, "api/v1.2.3", got[0].TagTemplate.Tag("1.2.3"))
+		assert.Equal(t, "web@1.2.3", got[1].TagTemplate.Tag("1.2.3"))
+	})
+
+	t.Run("global tag template", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			TagTemplate: "release-{{.Version}}",
+			Components:  []config.Component{{Name: "api"}},
+		}, "calver", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+
+		assert.Equal(t, "api/release-2024.01.0", got[0].TagTemplate.Tag("2024.01.0"))
+	})
+
+	t.Run("default calver tag template", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{}, "calver:YY.0M.MICRO", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+
+		assert.Equal(t, "24.01.0", got[0].TagTemplate.Tag("24.01.0"))
+	})
+
+	t.Run("invalid tag template", func(t *testing.T) {
+		_, err := parseComponents(&config.Config{TagTemplate: "release"}, "", "", []string{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("assets", func(t *testing.T) {
+		cfg := &config.Config{
+			Assets: []string{"dist/*"},
+			Components: []config.Component{
+				{Name: "api", Assets: []string{"api/dist/*"}},
+				{Name: "web"},
+			},
+		}
+
+		got, err := parseComponents(cfg, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, []string{"api/dist/*"}, got[0].Assets)
+		assert.Equal(t, []string{"dist/*"}, got[1].Assets)
+
+		got, err = parseComponents(cfg, "", "", []string{}, []string{"out/*.zip"})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, []string{"out/*.zip"}, got[0].Assets)
+		assert.Equal(t, []string{"out/*.zip"}, got[1].Assets)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Hooks: config.Hooks{BeforeCommit: []string{"npm install"}, AfterRelease: []string{"./announce.sh"}},
+			Components: []config.Component{
+				{Name: "api", Hooks: config.Hooks{BeforeCommit: []string{"make generate"}}},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, []string{"npm install", "make generate"}, got[0].BeforeCommitHooks)
+		assert.Equal(t, []string{"./announce.sh"}, got[0].AfterReleaseHooks)
+	})
+
+	t.Run("helm charts", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic", "-changelog", "helm"},
+			Helm:     config.Helm{Charts: []string{"charts/*/Chart.yaml"}},
+			Components: []config.Component{
+				{Name: "api", Helm: config.Helm{Charts: []string{"deploy/Chart.yaml"}}},
+				{Name: "web"},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		fsys := fstest.MapFS{
+			"deploy/Chart.yaml":     {Data: []byte("version: 1.0.0\n")},
+			"charts/web/Chart.yaml": {Data: []byte("version: 1.0.0\n")},
+		}
+		for i, want := range [][]string{{"deploy/Chart.yaml"}, {"charts/web/Chart.yaml"}} {
+			require.Len(t, got[i].Updaters, 1)
+			finder, ok := got[i].Updaters[0].(updater.FileFinder)
+			require.True(t, ok)
+
+			files, err := finder.FindFiles(fsys)
+			require.NoError(t, err)
+			assert.Equal(t, want, files)
+		}
+	})
+
+	t.Run("python init version", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic", "-changelog", "pyproject"},
+			Components: []config.Component{
+				{Name: "api", Python: config.Python{InitVersion: true}},
+				{Name: "web"},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		fsys := fstest.MapFS{
+			"pyproject.toml":  {Data: []byte("[project]\nname = \"foo\"\nversion = \"1.0.0\"\n")},
+			"foo/__init__.py": {Data: []byte("__version__ = \"1.0.0\"\n")},
+		}
+		for i, want := range [][]string{{"foo/__init__.py"}, nil} {
+			require.Len(t, got[i].Updaters, 1)
+			finder, ok := got[i].Updaters[0].(updater.FileFinder)
+			require.True(t, ok)
+
+			files, err := finder.FindFiles(fsys)
+			require.NoError(t, err)
+			assert.Equal(t, want, files)
+		}
+	})
+
+	t.Run("generic scan", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-changelog"},
+			Generic:  config.Generic{Ignore: []string{"node_modules"}},
+			Components: []config.Component{
+				{Name: "api", Generic: config.Generic{Scan: true, Ignore: []string{"dist"}}},
+				{Name: "web"},
+			},
+		}, "", "docs/**/*.md", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		fsys := fstest.MapFS{
+			"docs/install.md":           {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+			"version.txt":               {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+			"dist/version.txt":          {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+			"node_modules/foo/index.js": {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+		}
+		for i, want := range [][]string{{"docs/install.md", "version.txt"}, {"docs/install.md"}} {
+			require.Len(t, got[i].Updaters, 1)
+			finder, ok := got[i].Updaters[0].(updater.FileFinder)
+			require.True(t, ok)
+
+			files, err := finder.FindFiles(fsys)
+			require.NoError(t, err)
+			assert.Equal(t, want, files)
+		}
+	})
+
+	t.Run("jvm snapshot", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic", "-changelog", "maven"},
+			Components: []config.Component{
+				{Name: "api", JVM: config.JVM{Snapshot: true}},
+				{Name: "web"},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		_, ok := got[0].Updaters[0].(updater.PostReleaseUpdater)
+		assert.True(t, ok)
+		_, ok = got[1].Updaters[0].(updater.PostReleaseUpdater)
+		assert.False(t, ok)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic", "-changelog", "path"},
+			Paths:    []config.Path{{File: "manifest.json", Path: "version"}},
+			Components: []config.Component{
+				{Name: "api", Paths: []config.Path{{File: "values.yaml", Path: "image.tag", Prefix: "v"}}},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Len(t, got[0].Updaters, 2)
+		assert.Equal(t, []string{"manifest.json"}, got[0].Updaters[0].Files())
+		assert.Equal(t, []string{"values.yaml"}, got[0].Updaters[1].Files())
+
+		_, err = parseComponents(&config.Config{
+			Updaters: []string{"path"},
+			Paths:    []config.Path{{File: "manifest.json"}},
+		}, "", "", []string{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("regexes", func(t *testing.T) {
+		got, err := parseComponents(&config.Config{
+			Updaters: []string{"-generic", "-changelog", "regex"},
+			Regexes:  []config.Regex{{Files: []string{"Dockerfile"}, Pattern: `ARG VERSION_MAJOR=(?P<version>\d+)`, Template: "{{.Major}}"}},
+			Components: []config.Component{
+				{Name: "api", Regexes: []config.Regex{{Files: []string{"install.sh"}, Pattern: `VERSION="(?P<version>[^"]+)"`}}},
+			},
+		}, "", "", []string{}, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Len(t, got[0].Updaters, 2)
+		assert.Equal(t, []string{"Dockerfile"}, got[0].Updaters[0].Files())
+		assert.Equal(t, []string{"install.sh"}, got[0].Updaters[1].Files())
+
+		_, err = parseComponents(&config.Config{
+			Updaters: []string{"regex"},
+			Regexes:  []config.Regex{{Files: []string{"Dockerfile"}, Pattern: `VERSION=(\d+)`}},
+		}, "", "", []string{}, nil)
+		require.Error(t, err)
+
+		_, err = parseComponents(&config.Config{
+			Updaters: []string{"regex"},
+			Regexes:  []config.Regex{{Pattern: `VERSION=(?P<version>\d+)`}},
+		}, "", "", []string{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown updater", func(t *testing.T) {
+		_, err := parseComponents(&config.Config{
+			Components: []config.Component{{Name: "api", Updaters: []string{"foo"}}},
+		}, "", "", []string{}, nil)
+		require.Error(t, err)
+	})
+}
+
+func Test_parseVersioning(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		preMajorBumps bool
+		wantVersion   string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{name: "default", input: "", wantVersion: "1.0.0", wantErr: assert.NoError},
+		{name: "semver", input: "semver", wantVersion: "1.0.0", wantErr: assert.NoError},
+		{name: "calver", input: "calver", wantVersion: "2024.01.0", wantErr: assert.NoError},
+		{name: "calver with format", input: "calver:YY.0M.MICRO", wantVersion: "24.01.0", wantErr: assert.NoError},
+		{name: "invalid calver format", input: "calver:MAJOR", wantErr: assert.Error},
+		{name: "unknown", input: "foobar", wantErr: assert.Error},
+		{name: "semver with pre-major bumps", input: "semver", preMajorBumps: true, wantVersion: "0.1.0", wantErr: assert.NoError},
+		{name: "calver with pre-major bumps", input: "calver", preMajorBumps: true, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersioning(tt.input, tt.preMajorBumps)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			assert.True(t, got.IsValid(tt.wantVersion), "expected %q to be a valid version", tt.wantVersion)
+		})
+	}
+}
+
+func TestRunCommand_DryRun(t *testing.T) {
+	remote, err := fake.NewRemote("run-dry-run", "main")
+	require.NoError(t, err)
+	t.Cleanup(remote.Close)
+
+	hash, err := remote.Commit("main", "chore: init", nil)
+	require.NoError(t, err)
+	require.NoError(t, remote.Tag("v1.0.0", hash))
+	_, err = remote.Commit("main", "feat: foo", nil)
+	require.NoError(t, err)
+
+	githubOutput := filepath.Join(t.TempDir(), "github_output")
+	t.Setenv(EnvGitHubOutput, githubOutput)
+	dotenv := filepath.Join(t.TempDir(), "releaser-pleaser.env")
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"run", "--forge=git", "--remote-url", remote.URL(), "--dry-run", "--output-json", "-", "--output-dotenv", dotenv})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	require.NoError(t, cmd.Execute())
+
+	// stdout only contains the result, the diff is written to stderr
+	var result rp.RunResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	assert.True(t, result.Changed)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, rp.PullRequestCreated, result.PullRequests[0].Status)
+	assert.Contains(t, stderr.String(), "+## v1.1.0")
+
+	content, err := os.ReadFile(githubOutput)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "changed=true\ndry-run=true\n")
+
+	content, err = os.ReadFile(dotenv)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "RP_CHANGED=true\nRP_DRY_RUN=true\n")
+
+	_, err = remote.Head("releaser-pleaser--branches--main")
+	assert.Error(t, err)
+}
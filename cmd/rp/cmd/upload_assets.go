@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apricote/releaser-pleaser/internal/assets"
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/forge/dryrun"
+	"github.com/apricote/releaser-pleaser/internal/log"
+)
+
+func newUploadAssetsCommand() *cobra.Command {
+	var (
+		flagForge     forgeFlags
+		flagTag       string
+		flagChecksums bool
+		flagDryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upload-assets --tag <tag> <pattern>...",
+		Short: "Attach files matching the glob patterns to an existing release",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			logger := log.GetLogger(cmd.ErrOrStderr())
+
+			logger.DebugContext(ctx, "upload-assets called",
+				"forge", flagForge.forge,
+				"tag", flagTag,
+				"patterns", args,
+			)
+
+			f, err := flagForge.newForge(ctx, logger)
+			if err != nil {
+				return err
+			}
+
+			if flagDryRun {
+				logger.InfoContext(ctx, "running in dry-run mode, no changes are made to the repository")
+				f = dryrun.New(logger, f)
+			}
+
+			uploader, ok := f.(forge.AssetUploader)
+			if !ok {
+				return forge.ErrReleaseAssetsUnsupported
+			}
+
+			releaseAssets, err := assets.Find(args)
+			if err != nil {
+				return err
+			}
+
+			if flagChecksums {
+				dir, err := os.MkdirTemp("", "releaser-pleaser-assets-")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+
+				releaseAssets, err = assets.WithChecksums(dir, releaseAssets)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = uploader.UploadReleaseAssets(ctx, flagTag, releaseAssets)
+			if err != nil {
+				return err
+			}
+
+			logger.InfoContext(ctx, "Uploaded release assets", "release.title", flagTag, "release.url", f.ReleaseURL(flagTag), "assets.length", len(releaseAssets))
+
+			return nil
+		},
+	}
+
+	flagForge.register(cmd)
+	cmd.PersistentFlags().StringVar(&flagTag, "tag", "", "Tag of the release that the assets are attached to")
+	cmd.PersistentFlags().BoolVar(&flagChecksums, "checksums", true, "Upload a checksums.txt file with the SHA-256 checksums of the assets")
+	cmd.PersistentFlags().BoolVar(&flagDryRun, "dry-run", false, "Log all changes instead of applying them to the repository")
+	_ = cmd.MarkPersistentFlagRequired("tag")
+
+	return cmd
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/forge/gitlab"
+)
+
+// newUploadAssetsGitLab starts a GitLab API that records the uploaded package files and the created release links.
+func newUploadAssetsGitLab(t *testing.T) (uploaded map[string]string, links func() []string) {
+	t.Helper()
+
+	var (
+		mu      sync.Mutex
+		created []string
+	)
+	uploaded = map[string]string{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{project}/releases/{tag}/assets/links", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	mux.HandleFunc("PUT /api/v4/projects/{project}/packages/generic/{name}/{version}/{file}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bar", r.PathValue("name"))
+		assert.Equal(t, "v1.0.0", r.PathValue("version"))
+
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mu.Lock()
+		uploaded[r.PathValue("file")] = string(content)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte("{}"))
+	})
+	mux.HandleFunc("POST /api/v4/projects/{project}/releases/{tag}/assets/links", func(w http.ResponseWriter, r *http.Request) {
+		var link struct {
+			Name string `json:"name"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&link))
+
+		mu.Lock()
+		created = append(created, link.Name)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Setenv(gitlab.EnvAPIURL, server.URL+"/api/v4")
+	t.Setenv(gitlab.EnvAPIToken, "token")
+	t.Setenv(gitlab.EnvProjectURL, "")
+	t.Setenv(gitlab.EnvProjectPath, "")
+
+	return uploaded, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return created
+	}
+}
+
+func TestUploadAssetsCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantUploaded []string
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "double star pattern",
+			args:         []string{"--forge=gitlab", "dist/**/*.tar.gz"},
+			wantUploaded: []string{"app-linux.tar.gz", "app-darwin.tar.gz", "checksums.txt"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "without checksums",
+			args:         []string{"--forge=gitlab", "--checksums=false", "dist/*.tar.gz", "README.md"},
+			wantUploaded: []string{"app-linux.tar.gz", "README.md"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "dry run",
+			args:         []string{"--forge=gitlab", "--dry-run", "dist/**/*.tar.gz"},
+			wantUploaded: []string{},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "pattern without match",
+			args:         []string{"--forge=gitlab", "dist/*.deb"},
+			wantUploaded: []string{},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "forge without assets",
+			args:         []string{"--forge=git", "--remote-url=https://example.com/foo/bar.git", "dist/*.tar.gz"},
+			wantUploaded: []string{},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, forge.ErrReleaseAssetsUnsupported)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range map[string]string{
+				"dist/app-linux.tar.gz":         "linux",
+				"dist/darwin/app-darwin.tar.gz": "darwin",
+				"README.md":                     "readme",
+			} {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+			t.Chdir(dir)
+
+			uploaded, links := newUploadAssetsGitLab(t)
+
+			cmd := NewRootCmd()
+			cmd.SetArgs(append([]string{"upload-assets", "--tag=v1.0.0", "--owner=foo", "--repo=bar"}, tt.args...))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			err := cmd.Execute()
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			names := make([]string, 0, len(uploaded))
+			for name := range uploaded {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.wantUploaded, names)
+			assert.ElementsMatch(t, tt.wantUploaded, links())
+
+			if checksums, ok := uploaded["checksums.txt"]; ok {
+				assert.Contains(t, checksums, "  app-darwin.tar.gz\n")
+			}
+		})
+	}
+}
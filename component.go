@@ -0,0 +1,98 @@
+package rp
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/updater"
+	"github.com/apricote/releaser-pleaser/internal/versioning"
+)
+
+// Component is a part of the repository that is versioned and released independently. Repositories without any
+// configured components have a single Component with an empty Name and Path.
+type Component struct {
+	// Name is used in the branch of the release pull request and is available in the tag template. It is empty for the
+	// root component.
+	Name string
+	// Path is the directory of the component relative to the repository root. Only commits touching files in this
+	// directory are considered for the component. The files of the updaters are relative to this directory.
+	Path string
+
+	// TagTemplate builds the tag names of the component from the versions, eg. `v1.2.3` or `api/v1.2.3`. Tags that
+	// do not match the template are ignored.
+	TagTemplate versioning.TagTemplate
+
+	Updaters []updater.Updater
+
+	// BeforeCommitHooks are shell commands that run in the directory of the component in the clone, after the updaters
+	// and before the release commit is created. All changes they make are included in the release commit.
+	BeforeCommitHooks []string
+	// AfterReleaseHooks are shell commands that run in the working directory after a release of the component was
+	// created.
+	AfterReleaseHooks []string
+
+	// Assets are glob patterns of local files that are attached to the releases of the component, together with a
+	// checksums file. The patterns are relative to the working directory.
+	Assets []string
+}
+
+// releaseTags returns the tags of the component that are releases according to the versioning strategy.
+func (c Component) releaseTags(strategy versioning.Strategy) versioning.ReleaseTags {
+	return versioning.ReleaseTags{Template: c.TagTemplate, Strategy: strategy}
+}
+
+func (c Component) pullRequestBranch(targetBranch string) string {
+	if c.Name == "" {
+		return fmt.Sprintf(PullRequestBranchFormat, targetBranch)
+	}
+
+	return fmt.Sprintf(PullRequestComponentBranchFormat, targetBranch, c.Name)
+}
+
+func (c Component) cleanPath() string {
+	p := path.Clean(c.Path)
+	if p == "." || p == "/" {
+		return ""
+	}
+
+	return strings.TrimPrefix(p, "/")
+}
+
+// filePath returns the path of file relative to the repository root.
+func (c Component) filePath(file string) string {
+	return path.Join(c.cleanPath(), file)
+}
+
+// containsFile checks if the file (relative to the repository root) belongs to the component.
+func (c Component) containsFile(file string) bool {
+	p := c.cleanPath()
+	if p == "" {
+		return true
+	}
+
+	return file == p || strings.HasPrefix(file, p+"/")
+}
+
+// tagVersions replaces the names of the tags with the versions extracted through the TagTemplate, so that they can be
+// parsed by the versioning.Strategy.
+func (c Component) tagVersions(releases git.Releases) git.Releases {
+	version := func(tag *git.Tag) *git.Tag {
+		if tag == nil {
+			return nil
+		}
+
+		name, ok := c.TagTemplate.Version(tag.Name)
+		if !ok {
+			name = tag.Name
+		}
+
+		return &git.Tag{Hash: tag.Hash, Name: name}
+	}
+
+	return git.Releases{
+		Latest: version(releases.Latest),
+		Stable: version(releases.Stable),
+	}
+}
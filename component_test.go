@@ -0,0 +1,97 @@
+package rp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/versioning"
+)
+
+func TestComponent_pullRequestBranch(t *testing.T) {
+	assert.Equal(t, "releaser-pleaser--branches--main", Component{}.pullRequestBranch("main"))
+	assert.Equal(t, "releaser-pleaser--branches--main--components--api", Component{Name: "api"}.pullRequestBranch("main"))
+}
+
+func TestComponent_filePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		file      string
+		want      string
+	}{
+		{name: "root", component: Component{}, file: "CHANGELOG.md", want: "CHANGELOG.md"},
+		{name: "dot path", component: Component{Path: "."}, file: "CHANGELOG.md", want: "CHANGELOG.md"},
+		{name: "nested", component: Component{Path: "services/api/"}, file: "package.json", want: "services/api/package.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.component.filePath(tt.file))
+		})
+	}
+}
+
+func TestComponent_containsFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		file      string
+		want      bool
+	}{
+		{name: "root", component: Component{}, file: "api/main.go", want: true},
+		{name: "inside", component: Component{Path: "api"}, file: "api/main.go", want: true},
+		{name: "inside with slashes", component: Component{Path: "./api/"}, file: "api/main.go", want: true},
+		{name: "outside", component: Component{Path: "api"}, file: "web/main.go", want: false},
+		{name: "same prefix", component: Component{Path: "api"}, file: "api-docs/main.go", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.component.containsFile(tt.file))
+		})
+	}
+}
+
+func TestComponent_tagVersions(t *testing.T) {
+	component := Component{Name: "api", TagTemplate: newTestTagTemplate(t, "{{.Component}}/v{{.Version}}", "api")}
+
+	got := component.tagVersions(git.Releases{
+		Latest: &git.Tag{Hash: "abc", Name: "api/v1.1.0-rc.0"},
+		Stable: &git.Tag{Hash: "def", Name: "api/v1.0.0"},
+	})
+	assert.Equal(t, git.Releases{
+		Latest: &git.Tag{Hash: "abc", Name: "1.1.0-rc.0"},
+		Stable: &git.Tag{Hash: "def", Name: "1.0.0"},
+	}, got)
+
+	assert.Equal(t, git.Releases{}, component.tagVersions(git.Releases{}))
+}
+
+func TestReleaserPleaser_componentForTag(t *testing.T) {
+	rp := &ReleaserPleaser{
+		versioning: versioning.SemVer,
+		components: []Component{
+			{Name: "api", TagTemplate: newTestTagTemplate(t, "{{.Component}}/v{{.Version}}", "api")},
+			{Name: "api/v2", TagTemplate: newTestTagTemplate(t, "{{.Component}}/v{{.Version}}", "api/v2")},
+			{Name: "web", TagTemplate: newTestTagTemplate(t, "{{.Component}}@{{.Version}}", "web")},
+		},
+	}
+
+	tests := []struct {
+		tag           string
+		wantComponent string
+		wantVersion   string
+	}{
+		{tag: "api/v1.0.0", wantComponent: "api", wantVersion: "1.0.0"},
+		{tag: "api/v2/v2.0.0", wantComponent: "api/v2", wantVersion: "2.0.0"},
+		{tag: "web@1.0.0-rc.0", wantComponent: "web", wantVersion: "1.0.0-rc.0"},
+		{tag: "v1.0.0", wantComponent: "", wantVersion: "v1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			component, version := rp.componentForTag(tt.tag)
+			assert.Equal(t, tt.wantComponent, component.Name)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/glob"
+)
+
+// ChecksumsFile is the name of the asset with the checksums of all other assets.
+const ChecksumsFile = "checksums.txt"
+
+// Find expands the glob patterns into release assets, directories are skipped. A `**` in the patterns matches any
+// number of directories. The name of each asset is the base
+// name of the file. Every pattern needs to match at least one file and two files can not have the same name.
+func Find(patterns []string) ([]forge.ReleaseAsset, error) {
+	var assets []forge.ReleaseAsset
+
+	for _, pattern := range patterns {
+		matches, err := glob.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset pattern %q: %w", pattern, err)
+		}
+
+		found := false
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				continue
+			}
+			found = true
+
+			if slices.ContainsFunc(assets, func(a forge.ReleaseAsset) bool { return a.Path == match }) {
+				continue
+			}
+
+			assets, err = appendAsset(assets, forge.ReleaseAsset{Name: filepath.Base(match), Path: match})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("asset pattern %q does not match any files", pattern)
+		}
+	}
+
+	return assets, nil
+}
+
+// WithChecksums writes the SHA-256 checksums of the assets to ChecksumsFile in dir and returns the assets together
+// with the checksums file. The format is the same as the output of `sha256sum`, so the assets can be verified with
+// `sha256sum --check checksums.txt`.
+func WithChecksums(dir string, assets []forge.ReleaseAsset) ([]forge.ReleaseAsset, error) {
+	lines := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		checksum, err := sha256File(asset.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate checksum of %s: %w", asset.Name, err)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s  %s\n", checksum, asset.Name))
+	}
+	slices.SortFunc(lines, func(a, b string) int {
+		// Sort by name, the checksum has a fixed length
+		return strings.Compare(a[sha256.Size*2:], b[sha256.Size*2:])
+	})
+
+	checksums := forge.ReleaseAsset{Name: ChecksumsFile, Path: filepath.Join(dir, ChecksumsFile)}
+	if err := os.WriteFile(checksums.Path, []byte(strings.Join(lines, "")), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write checksums file: %w", err)
+	}
+
+	return appendAsset(slices.Clone(assets), checksums)
+}
+
+func appendAsset(assets []forge.ReleaseAsset, asset forge.ReleaseAsset) ([]forge.ReleaseAsset, error) {
+	if i := slices.IndexFunc(assets, func(a forge.ReleaseAsset) bool { return a.Name == asset.Name }); i >= 0 {
+		return nil, fmt.Errorf("multiple assets are named %s: %s and %s", asset.Name, assets[i].Path, asset.Path)
+	}
+
+	return append(assets, asset), nil
+}
+
+func sha256File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,149 @@
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+func TestFind(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"dist/app-linux.tar.gz":  "linux",
+		"dist/app-darwin.tar.gz": "darwin",
+		"dist/sub/app-linux.zip": "zip",
+		"other/app-linux.tar.gz": "duplicate name",
+		"README.md":              "readme",
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []forge.ReleaseAsset
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "no patterns",
+			patterns: nil,
+			want:     nil,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "glob skips directories",
+			patterns: []string{"dist/*"},
+			want: []forge.ReleaseAsset{
+				{Name: "app-darwin.tar.gz", Path: filepath.Join(dir, "dist/app-darwin.tar.gz")},
+				{Name: "app-linux.tar.gz", Path: filepath.Join(dir, "dist/app-linux.tar.gz")},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "double star",
+			patterns: []string{"dist/**/*-linux.*"},
+			want: []forge.ReleaseAsset{
+				{Name: "app-linux.tar.gz", Path: filepath.Join(dir, "dist/app-linux.tar.gz")},
+				{Name: "app-linux.zip", Path: filepath.Join(dir, "dist/sub/app-linux.zip")},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "overlapping patterns",
+			patterns: []string{"dist/*.tar.gz", "dist/app-linux.tar.gz", "README.md"},
+			want: []forge.ReleaseAsset{
+				{Name: "app-darwin.tar.gz", Path: filepath.Join(dir, "dist/app-darwin.tar.gz")},
+				{Name: "app-linux.tar.gz", Path: filepath.Join(dir, "dist/app-linux.tar.gz")},
+				{Name: "README.md", Path: filepath.Join(dir, "README.md")},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "no match",
+			patterns: []string{"dist/*.deb"},
+			want:     nil,
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "only directories",
+			patterns: []string{"dist/sub*"},
+			want:     nil,
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "duplicate name",
+			patterns: []string{"dist/*.tar.gz", "other/*"},
+			want:     nil,
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "invalid pattern",
+			patterns: []string{"dist/["},
+			want:     nil,
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns := make([]string, 0, len(tt.patterns))
+			for _, pattern := range tt.patterns {
+				patterns = append(patterns, filepath.Join(dir, pattern))
+			}
+
+			got, err := Find(patterns)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithChecksums(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"b.txt": "bar\n",
+		"a.txt": "foo\n",
+	})
+	checksumsDir := t.TempDir()
+
+	input := []forge.ReleaseAsset{
+		{Name: "b.txt", Path: filepath.Join(dir, "b.txt")},
+		{Name: "a.txt", Path: filepath.Join(dir, "a.txt")},
+	}
+
+	got, err := WithChecksums(checksumsDir, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, append(input, forge.ReleaseAsset{Name: ChecksumsFile, Path: filepath.Join(checksumsDir, ChecksumsFile)}), got)
+
+	content, err := os.ReadFile(filepath.Join(checksumsDir, ChecksumsFile))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c  a.txt\n"+
+			"7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730  b.txt\n",
+		string(content),
+	)
+}
+
+func TestWithChecksums_Conflict(t *testing.T) {
+	dir := writeFiles(t, map[string]string{ChecksumsFile: "existing"})
+
+	_, err := WithChecksums(t.TempDir(), []forge.ReleaseAsset{{Name: ChecksumsFile, Path: filepath.Join(dir, ChecksumsFile)}})
+	assert.Error(t, err)
+}
@@ -3,9 +3,12 @@ package changelog
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
 	"log"
 	"log/slog"
+	"slices"
 	"text/template"
+	"time"
 
 	"github.com/apricote/releaser-pleaser/internal/commitparser"
 	"github.com/apricote/releaser-pleaser/internal/markdown"
@@ -20,7 +23,7 @@ var rawChangelogTemplate string
 
 func init() {
 	var err error
-	changelogTemplate, err = template.New("changelog").Parse(rawChangelogTemplate)
+	changelogTemplate, err = template.New("changelog").Funcs(Funcs()).Parse(rawChangelogTemplate)
 	if err != nil {
 		log.Fatalf("failed to parse changelog template: %v", err)
 	}
@@ -30,18 +33,81 @@ func DefaultTemplate() *template.Template {
 	return changelogTemplate
 }
 
+// Parse parses a custom template. The template has access to the functions from Funcs and can use the templates
+// defined by DefaultTemplate, eg. `{{ template "entry" . }}` to render a single commit.
+func Parse(name, text string) (*template.Template, error) {
+	base, err := changelogTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := base.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+
+	return tpl, nil
+}
+
+// Templates are the templates for the places the changelog entry is shown in.
+type Templates struct {
+	// Changelog renders the entry that is added to the changelog file. Defaults to DefaultTemplate.
+	Changelog *template.Template
+	// PullRequest renders the changelog in the description of the release pull request. Defaults to DefaultTemplate.
+	PullRequest *template.Template
+	// Release renders the description of the release on the forge. If nil, the changelog from the release pull request
+	// is used.
+	Release *template.Template
+}
+
+// OrDefault returns tpl, or DefaultTemplate if tpl is nil.
+func OrDefault(tpl *template.Template) *template.Template {
+	if tpl == nil {
+		return DefaultTemplate()
+	}
+
+	return tpl
+}
+
 type Data struct {
-	Commits     map[string][]commitparser.AnalyzedCommit
+	Commits map[string][]commitparser.AnalyzedCommit
+	// Sections are the commits grouped by the visible types, in the order of the types. Empty sections are omitted.
+	Sections    []Section
 	Version     string
 	VersionLink string
 	CompareURL  string
 	Prefix      string
 	Suffix      string
+
+	// ReleaseDate is the date of the run that created or updated the release pull request.
+	ReleaseDate time.Time
+	// PreviousVersion is the tag of the release the changelog is compared to. It is empty for the first release.
+	PreviousVersion string
+	// Bump is the version bump that was applied for the release: "major", "minor" or "patch". It is the lowered bump if
+	// the versioning strategy has pre-major bumps.
+	Bump string
+	// Authors are the unique authors of all commits in Sections, in order of appearance.
+	Authors []string
+}
+
+type Section struct {
+	Type    string
+	Title   string
+	Commits []commitparser.AnalyzedCommit
 }
 
-func New(commits map[string][]commitparser.AnalyzedCommit, version, versionLink, compareURL, prefix, suffix string) Data {
+func New(commits map[string][]commitparser.AnalyzedCommit, types commitparser.CommitTypes, version, versionLink, compareURL, prefix, suffix string) Data {
+	sections := newSections(commits, types)
+
+	sectionCommits := make([][]commitparser.AnalyzedCommit, 0, len(sections))
+	for _, section := range sections {
+		sectionCommits = append(sectionCommits, section.Commits)
+	}
+
 	return Data{
 		Commits:     commits,
+		Sections:    sections,
+		Authors:     authors(slices.Concat(sectionCommits...)),
 		Version:     version,
 		VersionLink: versionLink,
 		CompareURL:  compareURL,
@@ -50,6 +116,24 @@ func New(commits map[string][]commitparser.AnalyzedCommit, version, versionLink,
 	}
 }
 
+func newSections(commits map[string][]commitparser.AnalyzedCommit, types commitparser.CommitTypes) []Section {
+	var result []Section
+
+	for _, commitType := range types {
+		if commitType.Hidden || len(commits[commitType.Type]) == 0 {
+			continue
+		}
+
+		result = append(result, Section{
+			Type:    commitType.Type,
+			Title:   commitType.Section,
+			Commits: commits[commitType.Type],
+		})
+	}
+
+	return result
+}
+
 type Formatting struct {
 	HideVersionTitle bool
 }
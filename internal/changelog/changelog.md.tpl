@@ -1,9 +1,9 @@
 {{define "entry" -}}
-- {{ if .BreakingChange}}**BREAKING**: {{end}}{{ if .Scope }}**{{.Scope}}**: {{end}}{{.Description}} ([{{.ShortHash}}]({{.URL}}))
+- {{ if .BreakingChange}}**BREAKING**: {{end}}{{ if .Scope }}**{{.Scope}}**: {{end}}{{.Description}} {{ if .URL }}([{{.ShortHash}}]({{.URL}})){{ else }}({{.ShortHash}}){{ end }}
 {{ end }}
 
 {{- if not .Formatting.HideVersionTitle }}
-## [{{.Data.Version}}]({{.Data.VersionLink}})
+## {{ if .Data.VersionLink }}[{{.Data.Version}}]({{.Data.VersionLink}}){{ else }}{{.Data.Version}}{{ end }}
 {{ if .Data.CompareURL }}
 [Compare to previous version]({{.Data.CompareURL}})
 {{ end -}}
@@ -11,15 +11,10 @@
 {{- if .Data.Prefix }}
 {{ .Data.Prefix }}
 {{ end -}}
-{{- with .Data.Commits.feat }}
-### Features
+{{- range .Data.Sections }}
+### {{ .Title }}
 
-{{ range . -}}{{template "entry" .}}{{end}}
-{{- end -}}
-{{- with .Data.Commits.fix }}
-### Bug Fixes
-
-{{ range . -}}{{template "entry" .}}{{end}}
+{{ range .Commits -}}{{template "entry" .}}{{end}}
 {{- end -}}
 
 {{- if .Data.Suffix }}
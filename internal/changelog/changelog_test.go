@@ -3,8 +3,10 @@ package changelog
 import (
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/apricote/releaser-pleaser/internal/commitparser"
 	"github.com/apricote/releaser-pleaser/internal/git"
@@ -18,6 +20,7 @@ func ptr[T any](input T) *T {
 func Test_NewChangelogEntry(t *testing.T) {
 	type args struct {
 		analyzedCommits []commitparser.AnalyzedCommit
+		types           commitparser.CommitTypes
 		version         string
 		link            string
 		compare         string
@@ -133,6 +136,57 @@ func Test_NewChangelogEntry(t *testing.T) {
 `,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "custom types",
+			args: args{
+				analyzedCommits: []commitparser.AnalyzedCommit{
+					{
+						Commit:      git.Commit{Hash: "aaa1111111111"},
+						Type:        "fix",
+						Description: "Foobar!",
+					},
+					{
+						Commit:      git.Commit{Hash: "bbb2222222222"},
+						Type:        "perf",
+						Description: "Faster!",
+					},
+					{
+						Commit:      git.Commit{Hash: "ccc3333333333"},
+						Type:        "deps",
+						Description: "Update dependency",
+					},
+					{
+						Commit:      git.Commit{Hash: "ddd4444444444"},
+						Type:        "chore",
+						Description: "Not listed",
+					},
+				},
+				types: commitparser.CommitTypes{
+					{Type: "perf", Section: "Performance", Bump: commitparser.BumpPatch},
+					{Type: "fix", Section: "Bug Fixes", Bump: commitparser.BumpPatch},
+					{Type: "deps", Section: "Dependencies", Hidden: true, Bump: commitparser.BumpPatch},
+					{Type: "refactor", Section: "Refactoring", Bump: commitparser.BumpNone},
+				},
+				version: "1.0.0",
+			},
+			want:    "## 1.0.0\n\n### Performance\n\n- Faster! (bbb2222)\n\n### Bug Fixes\n\n- Foobar! (aaa1111)\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "no links",
+			args: args{
+				analyzedCommits: []commitparser.AnalyzedCommit{
+					{
+						Commit:      git.Commit{Hash: "abc1234567890"},
+						Type:        "fix",
+						Description: "Foobar!",
+					},
+				},
+				version: "1.0.0",
+			},
+			want:    "## 1.0.0\n\n### Bug Fixes\n\n- Foobar! (abc1234)\n",
+			wantErr: assert.NoError,
+		},
 		{
 			name: "compare url",
 			args: args{
@@ -188,7 +242,12 @@ func Test_NewChangelogEntry(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			data := New(commitparser.ByType(tt.args.analyzedCommits), tt.args.version, tt.args.link, tt.args.compare, tt.args.prefix, tt.args.suffix)
+			types := tt.args.types
+			if types == nil {
+				types = commitparser.DefaultCommitTypes
+			}
+
+			data := New(commitparser.ByType(tt.args.analyzedCommits), types, tt.args.version, tt.args.link, tt.args.compare, tt.args.prefix, tt.args.suffix)
 			got, err := Entry(slog.Default(), DefaultTemplate(), data, Formatting{})
 			if !tt.wantErr(t, err) {
 				return
@@ -197,3 +256,68 @@ func Test_NewChangelogEntry(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	data := New(commitparser.ByType([]commitparser.AnalyzedCommit{
+		{
+			Commit:      git.Commit{Hash: "aaa1111111111", Author: git.Author{Name: "Jane"}},
+			Type:        "feat",
+			Description: "Foo",
+			Scope:       ptr("api"),
+		},
+		{
+			Commit:      git.Commit{Hash: "bbb2222222222", Author: git.Author{Name: "John"}, PullRequest: &git.PullRequest{ID: 5, URL: "https://example.com/pulls/5", Author: "john"}},
+			Type:        "fix",
+			Description: "Bar",
+		},
+	}), commitparser.DefaultCommitTypes, "v1.1.0", "", "", "", "")
+	data.ReleaseDate = time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	data.PreviousVersion = "v1.0.0"
+	data.Bump = "minor"
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "data",
+			text:    `{{ .Data.Version }} ({{ .Data.Bump }}) after {{ .Data.PreviousVersion }} on {{ date "2006-01-02" .Data.ReleaseDate }} by {{ .Data.Authors | join ", " }}`,
+			want:    "v1.1.0 (minor) after v1.0.0 on 2024-03-15 by Jane, @john\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "group by scope and default entry",
+			text: `{{ range .Data.Sections }}{{ range groupByScope .Commits }}
+### {{ or .Scope "other" }}
+
+{{ range .Commits }}{{ template "entry" . }}{{ end }}{{ end }}{{ end }}`,
+			want:    "### api\n\n- **api**: Foo (aaa1111)\n\n### other\n\n- Bar (bbb2222)\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "pull request links",
+			text:    `{{ range .Data.Commits.fix }}{{ .Description }} in [#{{ .PullRequest.ID }}]({{ .PullRequest.URL }}){{ end }}`,
+			want:    "Bar in [#5](https://example.com/pulls/5)\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid template",
+			text:    `{{ .Data.Version `,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl, err := Parse("custom.md.tpl", tt.text)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			got, err := Entry(slog.Default(), tpl, data, Formatting{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
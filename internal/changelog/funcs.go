@@ -0,0 +1,93 @@
+package changelog
+
+import (
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+)
+
+// Funcs returns the functions that are available in all changelog templates:
+//
+//   - `date "2006-01-02" .Data.ReleaseDate` formats the time with the Go layout.
+//   - `join ", " .Data.Authors` concatenates the strings with the separator.
+//   - `groupByScope .Commits` groups the commits by their scope, see ScopeGroup.
+//   - `authors .Commits` returns the unique authors of the commits.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"date":         formatDate,
+		"join":         join,
+		"groupByScope": groupByScope,
+		"authors":      authors,
+	}
+}
+
+func formatDate(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+// join takes the separator first, so that it can be used at the end of a pipeline.
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+// ScopeGroup are the commits with the same scope. Commits without a scope have an empty Scope.
+type ScopeGroup struct {
+	Scope   string
+	Commits []commitparser.AnalyzedCommit
+}
+
+// groupByScope groups the commits by scope. The groups are ordered by the first appearance of the scope, with the
+// commits without scope first.
+func groupByScope(commits []commitparser.AnalyzedCommit) []ScopeGroup {
+	groups := []ScopeGroup{}
+
+	for _, commit := range commits {
+		scope := ""
+		if commit.Scope != nil {
+			scope = *commit.Scope
+		}
+
+		i := slices.IndexFunc(groups, func(g ScopeGroup) bool { return g.Scope == scope })
+		if i < 0 {
+			groups = append(groups, ScopeGroup{Scope: scope})
+			i = len(groups) - 1
+		}
+
+		groups[i].Commits = append(groups[i].Commits, commit)
+	}
+
+	slices.SortStableFunc(groups, func(a, b ScopeGroup) int {
+		switch {
+		case a.Scope == "" && b.Scope != "":
+			return -1
+		case a.Scope != "" && b.Scope == "":
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return groups
+}
+
+// authors returns the unique names of the commit authors. The username of the pull request author is used for commits
+// that were merged through a pull request.
+func authors(commits []commitparser.AnalyzedCommit) []string {
+	result := []string{}
+
+	for _, commit := range commits {
+		name := commit.Author.Name
+		if commit.PullRequest != nil && commit.PullRequest.Author != "" {
+			name = "@" + commit.PullRequest.Author
+		}
+
+		if name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
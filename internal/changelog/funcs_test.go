@@ -0,0 +1,79 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+	"github.com/apricote/releaser-pleaser/internal/git"
+)
+
+func Test_groupByScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []commitparser.AnalyzedCommit
+		want    []ScopeGroup
+	}{
+		{
+			name:    "empty",
+			commits: []commitparser.AnalyzedCommit{},
+			want:    []ScopeGroup{},
+		},
+		{
+			name: "scopes in order of appearance, without scope first",
+			commits: []commitparser.AnalyzedCommit{
+				{Description: "a", Scope: ptr("api")},
+				{Description: "b"},
+				{Description: "c", Scope: ptr("db")},
+				{Description: "d", Scope: ptr("api")},
+			},
+			want: []ScopeGroup{
+				{Scope: "", Commits: []commitparser.AnalyzedCommit{{Description: "b"}}},
+				{Scope: "api", Commits: []commitparser.AnalyzedCommit{{Description: "a", Scope: ptr("api")}, {Description: "d", Scope: ptr("api")}}},
+				{Scope: "db", Commits: []commitparser.AnalyzedCommit{{Description: "c", Scope: ptr("db")}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupByScope(tt.commits))
+		})
+	}
+}
+
+func Test_authors(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []commitparser.AnalyzedCommit
+		want    []string
+	}{
+		{
+			name:    "empty",
+			commits: []commitparser.AnalyzedCommit{},
+			want:    []string{},
+		},
+		{
+			name: "unique commit authors",
+			commits: []commitparser.AnalyzedCommit{
+				{Commit: git.Commit{Author: git.Author{Name: "Jane"}}},
+				{Commit: git.Commit{Author: git.Author{Name: "John"}}},
+				{Commit: git.Commit{Author: git.Author{Name: "Jane"}}},
+			},
+			want: []string{"Jane", "John"},
+		},
+		{
+			name: "pull request author",
+			commits: []commitparser.AnalyzedCommit{
+				{Commit: git.Commit{Author: git.Author{Name: "Jane"}, PullRequest: &git.PullRequest{Author: "jane-doe"}}},
+				{Commit: git.Commit{Author: git.Author{Name: "John"}, PullRequest: &git.PullRequest{}}},
+			},
+			want: []string{"@jane-doe", "John"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, authors(tt.commits))
+		})
+	}
+}
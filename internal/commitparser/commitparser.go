@@ -14,6 +14,51 @@ type AnalyzedCommit struct {
 	Description    string
 	Scope          *string
 	BreakingChange bool
+	// ReleaseAs is the version from the `Release-As` footer of the commit. It overrides the calculated version.
+	ReleaseAs string
+}
+
+// Bump is the part of the version that is incremented for commits of a CommitType.
+type Bump string
+
+const (
+	BumpNone  Bump = "none"
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+// CommitType describes how commits of a type are released.
+type CommitType struct {
+	// Type is the conventional commit type, eg. `feat`.
+	Type string
+	// Section is the heading of the changelog section that lists the commits of this type.
+	Section string
+	// Hidden commits are not listed in the changelog, but still trigger their Bump.
+	Hidden bool
+	// Bump is the version bump for commits of this type. Breaking changes always bump the major version.
+	Bump Bump
+}
+
+// CommitTypes is the ordered list of known types. The order is used for the sections of the changelog. Commits with
+// other types are ignored, unless they are breaking changes.
+type CommitTypes []CommitType
+
+// DefaultCommitTypes are used if no types are configured.
+var DefaultCommitTypes = CommitTypes{
+	{Type: "feat", Section: "Features", Bump: BumpMinor},
+	{Type: "fix", Section: "Bug Fixes", Bump: BumpPatch},
+}
+
+// Get returns the CommitType with the name.
+func (t CommitTypes) Get(name string) (CommitType, bool) {
+	for _, commitType := range t {
+		if commitType.Type == name {
+			return commitType, true
+		}
+	}
+
+	return CommitType{}, false
 }
 
 // ByType groups the Commits by the type field. Used by the Changelog.
@@ -14,17 +14,24 @@ import (
 
 type Parser struct {
 	machine conventionalcommits.Machine
+	types   commitparser.CommitTypes
 	logger  *slog.Logger
 }
 
-func NewParser(logger *slog.Logger) *Parser {
+// FooterReleaseAs is the footer that forces the version of the next release, eg. `Release-As: 2.0.0`.
+const FooterReleaseAs = "release-as"
+
+// NewParser returns a Parser that only keeps commits with one of the types, commits that are breaking changes or
+// commits with a FooterReleaseAs.
+func NewParser(logger *slog.Logger, types commitparser.CommitTypes) *Parser {
 	parserMachine := parser.NewMachine(
 		parser.WithBestEffort(),
-		parser.WithTypes(conventionalcommits.TypesConventional),
+		parser.WithTypes(conventionalcommits.TypesFreeForm),
 	)
 
 	return &Parser{
 		machine: parserMachine,
+		types:   types,
 		logger:  logger,
 	}
 }
@@ -54,15 +61,22 @@ func (c *Parser) Analyze(commits []git.Commit) ([]commitparser.AnalyzedCommit, e
 			continue
 		}
 
-		commitVersionBump := conventionalCommit.VersionBump(conventionalcommits.DefaultStrategy)
-		if commitVersionBump > conventionalcommits.UnknownVersion {
-			// We only care about releasable commits
+		var releaseAs string
+		// Footer names are normalized to lowercase by the parser
+		if values := conventionalCommit.Footers[FooterReleaseAs]; len(values) > 0 {
+			releaseAs = strings.TrimSpace(values[len(values)-1])
+		}
+
+		_, isKnownType := c.types.Get(conventionalCommit.Type)
+		if isKnownType || conventionalCommit.IsBreakingChange() || releaseAs != "" {
+			// We only care about commits that show up in the changelog or affect the version
 			analyzedCommits = append(analyzedCommits, commitparser.AnalyzedCommit{
 				Commit:         commit,
 				Type:           conventionalCommit.Type,
 				Description:    conventionalCommit.Description,
 				Scope:          conventionalCommit.Scope,
 				BreakingChange: conventionalCommit.IsBreakingChange(),
+				ReleaseAs:      releaseAs,
 			})
 		}
 
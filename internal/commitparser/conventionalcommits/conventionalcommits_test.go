@@ -14,6 +14,7 @@ func TestAnalyzeCommits(t *testing.T) {
 	tests := []struct {
 		name            string
 		commits         []git.Commit
+		types           commitparser.CommitTypes
 		expectedCommits []commitparser.AnalyzedCommit
 		wantErr         assert.ErrorAssertionFunc
 	}{
@@ -143,10 +144,92 @@ func TestAnalyzeCommits(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "custom types",
+			commits: []git.Commit{
+				{
+					Message: "perf: faster",
+				},
+				{
+					Message: "deps: update foo",
+				},
+				{
+					Message: "chore: foobar",
+				},
+				{
+					Message: "fix: blabla",
+				},
+			},
+			types: commitparser.CommitTypes{
+				{Type: "perf", Section: "Performance", Bump: commitparser.BumpPatch},
+				{Type: "deps", Section: "Dependencies", Bump: commitparser.BumpNone},
+			},
+			expectedCommits: []commitparser.AnalyzedCommit{
+				{
+					Commit:      git.Commit{Message: "perf: faster"},
+					Type:        "perf",
+					Description: "faster",
+				},
+				{
+					Commit:      git.Commit{Message: "deps: update foo"},
+					Type:        "deps",
+					Description: "update foo",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "keeps breaking change of unknown type",
+			commits: []git.Commit{
+				{
+					Message: "chore!: drop support for foo",
+				},
+			},
+			expectedCommits: []commitparser.AnalyzedCommit{
+				{
+					Commit:         git.Commit{Message: "chore!: drop support for foo"},
+					Type:           "chore",
+					Description:    "drop support for foo",
+					BreakingChange: true,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "release-as footer",
+			commits: []git.Commit{
+				{
+					Message: "feat: add foo\n\nRelease-As: 2.0.0",
+				},
+				{
+					Message: "chore: release 3.0.0\n\nRelease-As: 3.0.0",
+				},
+			},
+			expectedCommits: []commitparser.AnalyzedCommit{
+				{
+					Commit:      git.Commit{Message: "feat: add foo\n\nRelease-As: 2.0.0"},
+					Type:        "feat",
+					Description: "add foo",
+					ReleaseAs:   "2.0.0",
+				},
+				{
+					Commit:      git.Commit{Message: "chore: release 3.0.0\n\nRelease-As: 3.0.0"},
+					Type:        "chore",
+					Description: "release 3.0.0",
+					ReleaseAs:   "3.0.0",
+				},
+			},
+			wantErr: assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			analyzedCommits, err := NewParser(slog.Default()).Analyze(tt.commits)
+			types := tt.types
+			if types == nil {
+				types = commitparser.DefaultCommitTypes
+			}
+
+			analyzedCommits, err := NewParser(slog.Default(), types).Analyze(tt.commits)
 			if !tt.wantErr(t, err) {
 				return
 			}
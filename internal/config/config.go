@@ -0,0 +1,245 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the names of the configuration files that are looked up in the repository root. The first file that
+// exists is used, all others are ignored.
+var FileNames = []string{
+	".releaser-pleaser.yaml",
+	".releaser-pleaser.yml",
+	".releaser-pleaser.toml",
+}
+
+// FileReader returns the content of a file in the repository. If the file does not exist, it returns nil.
+type FileReader interface {
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+}
+
+// Config is the repository configuration of releaser-pleaser. Every field is optional, flags passed to `rp run` take
+// precedence over the values in the file.
+type Config struct {
+	Updaters      []string     `yaml:"updaters" toml:"updaters"`
+	ExtraFiles    []string     `yaml:"extra-files" toml:"extra-files"`
+	Assets        []string     `yaml:"assets" toml:"assets"`
+	Versioning    string       `yaml:"versioning" toml:"versioning"`
+	PreMajorBumps bool         `yaml:"pre-major-bumps" toml:"pre-major-bumps"`
+	TagTemplate   string       `yaml:"tag-template" toml:"tag-template"`
+	Changelog     Changelog    `yaml:"changelog" toml:"changelog"`
+	Labels        []Label      `yaml:"labels" toml:"labels"`
+	CommitTypes   []CommitType `yaml:"commit-types" toml:"commit-types"`
+	Templates     Templates    `yaml:"templates" toml:"templates"`
+	Hooks         Hooks        `yaml:"hooks" toml:"hooks"`
+	Generic       Generic      `yaml:"generic" toml:"generic"`
+	Helm          Helm         `yaml:"helm" toml:"helm"`
+	Python        Python       `yaml:"python" toml:"python"`
+	JVM           JVM          `yaml:"jvm" toml:"jvm"`
+	Paths         []Path       `yaml:"paths" toml:"paths"`
+	Regexes       []Regex      `yaml:"regexes" toml:"regexes"`
+
+	Components []Component `yaml:"components" toml:"components"`
+}
+
+// Component is a part of the repository that is versioned and released independently. The updaters, extra files and
+// changelog file are relative to Path.
+type Component struct {
+	Name        string    `yaml:"name" toml:"name"`
+	Path        string    `yaml:"path" toml:"path"`
+	TagTemplate string    `yaml:"tag-template" toml:"tag-template"`
+	Updaters    []string  `yaml:"updaters" toml:"updaters"`
+	ExtraFiles  []string  `yaml:"extra-files" toml:"extra-files"`
+	Changelog   Changelog `yaml:"changelog" toml:"changelog"`
+	// Assets replace the top-level assets for the component.
+	Assets  []string `yaml:"assets" toml:"assets"`
+	Hooks   Hooks    `yaml:"hooks" toml:"hooks"`
+	Generic Generic  `yaml:"generic" toml:"generic"`
+	Helm    Helm     `yaml:"helm" toml:"helm"`
+	Python  Python   `yaml:"python" toml:"python"`
+	JVM     JVM      `yaml:"jvm" toml:"jvm"`
+	Paths   []Path   `yaml:"paths" toml:"paths"`
+	Regexes []Regex  `yaml:"regexes" toml:"regexes"`
+}
+
+type Changelog struct {
+	// File is the path of the changelog file, relative to the repository root or the path of the component.
+	File string `yaml:"file" toml:"file"`
+}
+
+// Generic configures the generic updater.
+type Generic struct {
+	// Scan updates every file that contains the marker, in addition to the extra files.
+	Scan bool `yaml:"scan" toml:"scan"`
+	// Ignore are glob patterns of files and directories that are skipped by the scan, e.g. `node_modules` or `dist/**`.
+	Ignore []string `yaml:"ignore" toml:"ignore"`
+}
+
+// Helm configures the helm updater.
+type Helm struct {
+	// Charts are paths or glob patterns of Chart.yaml files. The charts of a component replace the top-level charts.
+	Charts []string `yaml:"charts" toml:"charts"`
+	// AppVersion also updates the appVersion of the charts.
+	AppVersion bool `yaml:"app-version" toml:"app-version"`
+}
+
+// Python configures the pyproject updater.
+type Python struct {
+	// InitVersion also updates the `__version__` variable in the `__init__.py` of the package.
+	InitVersion bool `yaml:"init-version" toml:"init-version"`
+}
+
+// JVM configures the maven and gradle updaters.
+type JVM struct {
+	// Snapshot sets the next snapshot version after a release, e.g. `1.2.4-SNAPSHOT` after `1.2.3`.
+	Snapshot bool `yaml:"snapshot" toml:"snapshot"`
+}
+
+// Path is a value in a JSON, YAML or TOML file that is set to the version by the path updater.
+type Path struct {
+	File string `yaml:"file" toml:"file"`
+	// Path is a list of keys separated by dots, e.g. `image.tag` or `$.version`.
+	Path string `yaml:"path" toml:"path"`
+	// Prefix is written before the version, e.g. `v`.
+	Prefix string `yaml:"prefix" toml:"prefix"`
+}
+
+// Regex replaces the capture group named `version` of a regular expression in files with the version, rendered by the
+// regex updater.
+type Regex struct {
+	Files []string `yaml:"files" toml:"files"`
+	// Pattern is a Go regular expression with a capture group named `version`, e.g. `ARG VERSION_MAJOR=(?P<version>\d+)`.
+	Pattern string `yaml:"pattern" toml:"pattern"`
+	// Template is a Go template for the replacement, e.g. `{{.Major}}`. Defaults to `{{.Version}}`.
+	Template string `yaml:"template" toml:"template"`
+}
+
+// Hooks are shell commands that run at fixed points of the release process.
+type Hooks struct {
+	// BeforeCommit runs in the clone after the updaters, the changes are included in the release commit.
+	BeforeCommit []string `yaml:"before-commit" toml:"before-commit"`
+	// AfterRelease runs in the working directory after a release was created.
+	AfterRelease []string `yaml:"after-release" toml:"after-release"`
+}
+
+// Templates are the paths of Go template files in the repository that replace the default changelog template.
+type Templates struct {
+	// Changelog renders the entry in the changelog file.
+	Changelog string `yaml:"changelog" toml:"changelog"`
+	// PullRequest renders the changelog in the description of the release pull request.
+	PullRequest string `yaml:"pull-request" toml:"pull-request"`
+	// Release renders the description of the release on the forge.
+	Release string `yaml:"release" toml:"release"`
+}
+
+// Label is an additional label that is added to every release pull request.
+type Label struct {
+	Name        string `yaml:"name" toml:"name"`
+	Color       string `yaml:"color" toml:"color"`
+	Description string `yaml:"description" toml:"description"`
+}
+
+// CommitType configures how commits of a conventional commit type are released. The configured types replace the
+// default types `feat` and `fix`.
+type CommitType struct {
+	Type string `yaml:"type" toml:"type"`
+	// Section is the heading in the changelog, it defaults to Type.
+	Section string `yaml:"section" toml:"section"`
+	Hidden  bool   `yaml:"hidden" toml:"hidden"`
+	// Bump is one of "none", "patch", "minor" or "major". Defaults to "none".
+	Bump string `yaml:"bump" toml:"bump"`
+}
+
+// Load looks for the first existing file from FileNames and parses it. If no file exists, an empty Config is returned.
+func Load(ctx context.Context, reader FileReader) (*Config, string, error) {
+	for _, name := range FileNames {
+		content, err := reader.ReadFile(ctx, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read config file %q: %w", name, err)
+		}
+		if content == nil {
+			continue
+		}
+
+		cfg, err := Parse(name, content)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return cfg, name, nil
+	}
+
+	return &Config{}, "", nil
+}
+
+// Parse reads the content of a configuration file. The format is chosen by the file extension of name.
+func Parse(name string, content []byte) (*Config, error) {
+	cfg := &Config{}
+
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		// An empty file returns io.EOF, which we do not consider an error.
+		if err := decoder.Decode(cfg); err != nil && len(bytes.TrimSpace(content)) > 0 {
+			return nil, fmt.Errorf("failed to parse config file %q: %w", name, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file %q: %w", name, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("failed to parse config file %q: unknown key %q", name, undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("unknown config file format: %s", ext)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %q: %w", name, err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	names := make(map[string]bool, len(c.Components))
+	for i, component := range c.Components {
+		if component.Name == "" {
+			return fmt.Errorf("component %d is missing a name", i)
+		}
+		if strings.ContainsAny(component.Name, " ~^:?*[\\") {
+			return fmt.Errorf("component name %q contains characters that are not allowed in tags", component.Name)
+		}
+		if names[component.Name] {
+			return fmt.Errorf("duplicate component name %q", component.Name)
+		}
+		names[component.Name] = true
+	}
+
+	types := make(map[string]bool, len(c.CommitTypes))
+	for i, commitType := range c.CommitTypes {
+		if commitType.Type == "" {
+			return fmt.Errorf("commit type %d is missing a type", i)
+		}
+		if types[commitType.Type] {
+			return fmt.Errorf("duplicate commit type %q", commitType.Type)
+		}
+		types[commitType.Type] = true
+
+		switch commitType.Bump {
+		case "", "none", "patch", "minor", "major":
+		default:
+			return fmt.Errorf("commit type %q has unknown bump %q, must be one of none, patch, minor or major", commitType.Type, commitType.Bump)
+		}
+	}
+
+	return nil
+}
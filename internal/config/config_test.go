@@ -0,0 +1,304 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapFileReader map[string]string
+
+func (m mapFileReader) ReadFile(_ context.Context, path string) ([]byte, error) {
+	content, ok := m[path]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(content), nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		want     *Config
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "empty yaml",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "",
+			want:     &Config{},
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "full yaml",
+			fileName: ".releaser-pleaser.yaml",
+			content: `updaters: [-generic, packagejson]
+extra-files:
+  - version.txt
+assets:
+  - dist/*.tar.gz
+versioning: semver
+pre-major-bumps: true
+tag-template: release-{{.Version}}
+changelog:
+  file: docs/CHANGELOG.md
+labels:
+  - name: release
+    color: 00FF00
+`,
+			want: &Config{
+				Updaters:      []string{"-generic", "packagejson"},
+				ExtraFiles:    []string{"version.txt"},
+				Assets:        []string{"dist/*.tar.gz"},
+				Versioning:    "semver",
+				PreMajorBumps: true,
+				TagTemplate:   "release-{{.Version}}",
+				Changelog:     Changelog{File: "docs/CHANGELOG.md"},
+				Labels:        []Label{{Name: "release", Color: "00FF00"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "full toml",
+			fileName: ".releaser-pleaser.toml",
+			content: `updaters = ["-generic", "packagejson"]
+extra-files = ["version.txt"]
+assets = ["dist/*.tar.gz"]
+versioning = "semver"
+pre-major-bumps = true
+tag-template = "release-{{.Version}}"
+
+[changelog]
+file = "docs/CHANGELOG.md"
+
+[[labels]]
+name = "release"
+color = "00FF00"
+`,
+			want: &Config{
+				Updaters:      []string{"-generic", "packagejson"},
+				ExtraFiles:    []string{"version.txt"},
+				Assets:        []string{"dist/*.tar.gz"},
+				Versioning:    "semver",
+				PreMajorBumps: true,
+				TagTemplate:   "release-{{.Version}}",
+				Changelog:     Changelog{File: "docs/CHANGELOG.md"},
+				Labels:        []Label{{Name: "release", Color: "00FF00"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "unknown key yaml",
+			fileName: ".releaser-pleaser.yml",
+			content:  "extra_files: [foo]\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "unknown key toml",
+			fileName: ".releaser-pleaser.toml",
+			content:  "extra_files = [\"foo\"]\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "components",
+			fileName: ".releaser-pleaser.yaml",
+			content: `components:
+  - name: api
+    path: services/api
+    updaters: [packagejson]
+    assets: [services/api/dist/*]
+  - name: web
+    path: web
+    tag-template: "{{.Component}}@{{.Version}}"
+    changelog:
+      file: CHANGES.md
+`,
+			want: &Config{
+				Components: []Component{
+					{Name: "api", Path: "services/api", Updaters: []string{"packagejson"}, Assets: []string{"services/api/dist/*"}},
+					{Name: "web", Path: "web", TagTemplate: "{{.Component}}@{{.Version}}", Changelog: Changelog{File: "CHANGES.md"}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "component without name",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "components:\n  - path: api\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "duplicate component",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "components:\n  - name: api\n  - name: api\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "commit types",
+			fileName: ".releaser-pleaser.yaml",
+			content: `commit-types:
+  - type: feat
+    section: Features
+    bump: minor
+  - type: deps
+    section: Dependencies
+    hidden: true
+    bump: patch
+`,
+			want: &Config{
+				CommitTypes: []CommitType{
+					{Type: "feat", Section: "Features", Bump: "minor"},
+					{Type: "deps", Section: "Dependencies", Hidden: true, Bump: "patch"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "templates",
+			fileName: ".releaser-pleaser.toml",
+			content: `[templates]
+changelog = ".github/changelog.md.tpl"
+pull-request = ".github/pull-request.md.tpl"
+release = ".github/release.md.tpl"
+`,
+			want: &Config{
+				Templates: Templates{
+					Changelog:   ".github/changelog.md.tpl",
+					PullRequest: ".github/pull-request.md.tpl",
+					Release:     ".github/release.md.tpl",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "updater options",
+			fileName: ".releaser-pleaser.toml",
+			content: `updaters = ["helm"]
+
+[generic]
+scan = true
+ignore = ["node_modules", "docs/changelog/**"]
+
+[helm]
+charts = ["deploy/*/Chart.yaml"]
+app-version = true
+
+[python]
+init-version = true
+
+[jvm]
+snapshot = true
+
+[[paths]]
+file = "deploy/values.yaml"
+path = "image.tag"
+prefix = "v"
+
+[[regexes]]
+files = ["Dockerfile"]
+pattern = 'ARG VERSION_MAJOR=(?P<version>\d+)'
+template = "{{.Major}}"
+`,
+			want: &Config{
+				Updaters: []string{"helm"},
+				Generic: Generic{
+					Scan:   true,
+					Ignore: []string{"node_modules", "docs/changelog/**"},
+				},
+				Helm: Helm{
+					Charts:     []string{"deploy/*/Chart.yaml"},
+					AppVersion: true,
+				},
+				Python: Python{InitVersion: true},
+				JVM:    JVM{Snapshot: true},
+				Paths: []Path{
+					{File: "deploy/values.yaml", Path: "image.tag", Prefix: "v"},
+				},
+				Regexes: []Regex{
+					{Files: []string{"Dockerfile"}, Pattern: `ARG VERSION_MAJOR=(?P<version>\d+)`, Template: "{{.Major}}"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "hooks",
+			fileName: ".releaser-pleaser.yaml",
+			content: `hooks:
+  before-commit:
+    - npm install --package-lock-only
+  after-release:
+    - ./scripts/announce.sh
+components:
+  - name: api
+    hooks:
+      before-commit: [make generate]
+`,
+			want: &Config{
+				Hooks: Hooks{
+					BeforeCommit: []string{"npm install --package-lock-only"},
+					AfterRelease: []string{"./scripts/announce.sh"},
+				},
+				Components: []Component{
+					{Name: "api", Hooks: Hooks{BeforeCommit: []string{"make generate"}}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "commit type without type",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "commit-types:\n  - section: Features\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "duplicate commit type",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "commit-types:\n  - type: feat\n  - type: feat\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "unknown bump",
+			fileName: ".releaser-pleaser.yaml",
+			content:  "commit-types:\n  - type: feat\n    bump: huge\n",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "unknown format",
+			fileName: ".releaser-pleaser.json",
+			content:  "{}",
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.fileName, []byte(tt.content))
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("no file", func(t *testing.T) {
+		cfg, name, err := Load(context.Background(), mapFileReader{})
+		require.NoError(t, err)
+		assert.Empty(t, name)
+		assert.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("first file wins", func(t *testing.T) {
+		cfg, name, err := Load(context.Background(), mapFileReader{
+			".releaser-pleaser.yml":  "versioning: semver\n",
+			".releaser-pleaser.toml": "versioning = \"other\"\n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ".releaser-pleaser.yml", name)
+		assert.Equal(t, "semver", cfg.Versioning)
+	})
+}
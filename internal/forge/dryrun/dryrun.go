@@ -0,0 +1,130 @@
+package dryrun
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+)
+
+var (
+	_ forge.Forge         = &DryRun{}
+	_ forge.AssetUploader = &DryRun{}
+)
+
+// DryRun wraps a forge.Forge and replaces every method that would change the repository with a log message describing
+// the intended change. All read-only methods are passed through to the wrapped forge.
+type DryRun struct {
+	forge.Forge
+
+	log *slog.Logger
+}
+
+func New(log *slog.Logger, f forge.Forge) *DryRun {
+	return &DryRun{
+		Forge: f,
+		log:   log.With("dry-run", true),
+	}
+}
+
+func (d *DryRun) EnsureLabelsExist(ctx context.Context, labels []releasepr.Label) error {
+	d.log.InfoContext(ctx, "would ensure that labels exist", "labels", labelNames(labels))
+
+	return nil
+}
+
+func (d *DryRun) CreatePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	d.log.InfoContext(ctx, "would create pull request",
+		"pr.title", pr.Title,
+		"pr.head", pr.Head,
+		"pr.labels", labelNames(pr.Labels),
+		"pr.description", pr.Description,
+	)
+
+	return nil
+}
+
+func (d *DryRun) UpdatePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	d.log.InfoContext(ctx, "would update pull request",
+		"pr.id", pr.ID,
+		"pr.title", pr.Title,
+		"pr.description", pr.Description,
+	)
+
+	return nil
+}
+
+func (d *DryRun) SetPullRequestLabels(ctx context.Context, pr *releasepr.ReleasePullRequest, remove, add []releasepr.Label) error {
+	d.log.InfoContext(ctx, "would update pull request labels",
+		"pr.id", pr.ID,
+		"labels.remove", labelNames(remove),
+		"labels.add", labelNames(add),
+	)
+
+	return nil
+}
+
+func (d *DryRun) ClosePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	d.log.InfoContext(ctx, "would close pull request", "pr.id", pr.ID, "pr.title", pr.Title)
+
+	return nil
+}
+
+func (d *DryRun) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, prerelease, latest bool) error {
+	d.log.InfoContext(ctx, "would create release",
+		"commit.hash", commit.Hash,
+		"release.title", title,
+		"release.prerelease", prerelease,
+		"release.latest", latest,
+		"release.changelog", changelog,
+	)
+
+	return nil
+}
+
+func (d *DryRun) CreateReleaseWithAssets(ctx context.Context, commit git.Commit, title, changelog string, prerelease, latest bool, assets []forge.ReleaseAsset) error {
+	if _, ok := d.Forge.(forge.AssetUploader); !ok {
+		return forge.ErrReleaseAssetsUnsupported
+	}
+
+	d.log.InfoContext(ctx, "would create release with assets",
+		"commit.hash", commit.Hash,
+		"release.title", title,
+		"release.prerelease", prerelease,
+		"release.latest", latest,
+		"release.assets", assetNames(assets),
+		"release.changelog", changelog,
+	)
+
+	return nil
+}
+
+func (d *DryRun) UploadReleaseAssets(ctx context.Context, tag string, assets []forge.ReleaseAsset) error {
+	if _, ok := d.Forge.(forge.AssetUploader); !ok {
+		return forge.ErrReleaseAssetsUnsupported
+	}
+
+	d.log.InfoContext(ctx, "would upload release assets", "release.title", tag, "release.assets", assetNames(assets))
+
+	return nil
+}
+
+func assetNames(assets []forge.ReleaseAsset) []string {
+	names := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+	}
+
+	return names
+}
+
+func labelNames(labels []releasepr.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+
+	return names
+}
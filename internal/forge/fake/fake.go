@@ -0,0 +1,465 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+)
+
+const (
+	PRStateOpen   = "open"
+	PRStateClosed = "closed"
+	PRStateMerged = "merged"
+)
+
+var (
+	_ forge.Forge         = &Forge{}
+	_ forge.AssetUploader = &Forge{}
+)
+
+// Forge is an in-memory forge.Forge for tests. Commits and tags are stored in the Remote, which releaser-pleaser
+// clones from and pushes to. Pull requests, labels and releases are stored in maps and can be inspected and modified
+// by the test.
+type Forge struct {
+	options *Options
+	log     *slog.Logger
+
+	Remote *Remote
+
+	mu sync.Mutex
+
+	Labels       map[string]releasepr.Label
+	PullRequests map[int64]*PullRequest
+	Releases     map[string]*Release
+	// CommitPullRequests associates commits on the base branch with the pull request they were merged in.
+	CommitPullRequests map[string]*git.PullRequest
+
+	// OnPullRequestByID is called with the stored pull request before it is returned from PullRequestByID. Tests can
+	// use it to simulate changes made by users while releaser-pleaser is running.
+	OnPullRequestByID func(pr *PullRequest)
+
+	nextPullRequestID int64
+}
+
+type PullRequest struct {
+	releasepr.ReleasePullRequest
+
+	State string
+}
+
+type Release struct {
+	Commit     git.Commit
+	Title      string
+	Changelog  string
+	Prerelease bool
+	Latest     bool
+	// Assets maps the names of the uploaded assets to their content.
+	Assets map[string][]byte
+}
+
+func (f *Forge) RepoURL() string {
+	return fmt.Sprintf("https://fake.example.com/%s", f.options.Repository)
+}
+
+func (f *Forge) CloneURL() string {
+	return f.Remote.URL()
+}
+
+func (f *Forge) ReleaseURL(version string) string {
+	return fmt.Sprintf("%s/releases/tag/%s", f.RepoURL(), version)
+}
+
+func (f *Forge) PullRequestURL(id int64) string {
+	return fmt.Sprintf("%s/pulls/%d", f.RepoURL(), id)
+}
+
+func (f *Forge) CommitURL(hash string) string {
+	return fmt.Sprintf("%s/commit/%s", f.RepoURL(), hash)
+}
+
+func (f *Forge) CompareURL(from, to string) string {
+	return fmt.Sprintf("%s/compare/%s...%s", f.RepoURL(), from, to)
+}
+
+func (f *Forge) GitAuth() transport.AuthMethod {
+	return nil
+}
+
+func (f *Forge) ReadFile(_ context.Context, path string) ([]byte, error) {
+	return f.Remote.File(f.options.BaseBranch, path)
+}
+
+func (f *Forge) CommitAuthor(_ context.Context) (git.Author, error) {
+	return f.options.Author, nil
+}
+
+func (f *Forge) LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error) {
+	return f.repository().LatestTags(ctx, releaseTags)
+}
+
+func (f *Forge) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit, error) {
+	commits, err := f.repository().CommitsSince(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range commits {
+		commits[i].URL = f.CommitURL(commits[i].Hash)
+		if pr, ok := f.CommitPullRequests[commits[i].Hash]; ok {
+			prCopy := *pr
+			commits[i].PullRequest = &prCopy
+		}
+	}
+
+	return commits, nil
+}
+
+// repository returns the Remote as a git.Repository. The HEAD of the Remote always points at the default branch.
+func (f *Forge) repository() *git.Repository {
+	return git.NewRepository(f.log, f.Remote.Repository(), nil)
+}
+
+func (f *Forge) EnsureLabelsExist(_ context.Context, labels []releasepr.Label) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, label := range labels {
+		if _, ok := f.Labels[label.Name]; !ok {
+			f.Labels[label.Name] = label
+		}
+	}
+
+	return nil
+}
+
+func (f *Forge) PullRequestForBranch(_ context.Context, branch string) (*releasepr.ReleasePullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range slices.Sorted(maps.Keys(f.PullRequests)) {
+		pr := f.PullRequests[id]
+		if pr.State == PRStateOpen && pr.Head == branch {
+			return pr.releasePullRequest(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *Forge) PullRequestByID(_ context.Context, pr *releasepr.ReleasePullRequest) (*releasepr.ReleasePullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.PullRequests[pr.ID]
+	if !ok {
+		return nil, nil
+	}
+
+	if f.OnPullRequestByID != nil {
+		f.OnPullRequestByID(stored)
+	}
+
+	return stored.releasePullRequest(), nil
+}
+
+func (f *Forge) CreatePullRequest(_ context.Context, pr *releasepr.ReleasePullRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.checkLabelsExist(pr.Labels); err != nil {
+		return err
+	}
+
+	f.nextPullRequestID++
+	pr.ID = f.nextPullRequestID
+
+	stored := &PullRequest{
+		ReleasePullRequest: *copyReleasePullRequest(pr),
+		State:              PRStateOpen,
+	}
+	stored.URL = f.PullRequestURL(pr.ID)
+	f.PullRequests[pr.ID] = stored
+
+	return nil
+}
+
+func (f *Forge) UpdatePullRequest(_ context.Context, pr *releasepr.ReleasePullRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.pullRequest(pr.ID)
+	if err != nil {
+		return err
+	}
+
+	stored.Title = pr.Title
+	stored.Description = pr.Description
+
+	return nil
+}
+
+func (f *Forge) SetPullRequestLabels(_ context.Context, pr *releasepr.ReleasePullRequest, remove, add []releasepr.Label) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.pullRequest(pr.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = f.checkLabelsExist(add); err != nil {
+		return err
+	}
+
+	stored.Labels = slices.DeleteFunc(stored.Labels, func(label releasepr.Label) bool {
+		return slices.ContainsFunc(remove, func(r releasepr.Label) bool { return r.Name == label.Name })
+	})
+	for _, label := range add {
+		if !slices.ContainsFunc(stored.Labels, func(l releasepr.Label) bool { return l.Name == label.Name }) {
+			stored.Labels = append(stored.Labels, label)
+		}
+	}
+
+	return nil
+}
+
+func (f *Forge) ClosePullRequest(_ context.Context, pr *releasepr.ReleasePullRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.pullRequest(pr.ID)
+	if err != nil {
+		return err
+	}
+
+	stored.State = PRStateClosed
+
+	return nil
+}
+
+func (f *Forge) PendingReleases(_ context.Context, pendingLabel releasepr.Label) ([]*releasepr.ReleasePullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var prs []*releasepr.ReleasePullRequest
+	for _, id := range slices.Sorted(maps.Keys(f.PullRequests)) {
+		pr := f.PullRequests[id]
+		if pr.State != PRStateMerged {
+			continue
+		}
+
+		if slices.ContainsFunc(pr.Labels, func(l releasepr.Label) bool { return l.Name == pendingLabel.Name }) {
+			prs = append(prs, pr.releasePullRequest())
+		}
+	}
+
+	return prs, nil
+}
+
+func (f *Forge) CreateRelease(_ context.Context, commit git.Commit, title, changelog string, prerelease, latest bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.createRelease(commit, title, changelog, prerelease, latest, nil)
+}
+
+// CreateReleaseWithAssets reads all assets before the release is created, so that no release is stored if an asset
+// can not be read.
+func (f *Forge) CreateReleaseWithAssets(_ context.Context, commit git.Commit, title, changelog string, prerelease, latest bool, assets []forge.ReleaseAsset) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	content, err := readAssets(assets)
+	if err != nil {
+		return err
+	}
+
+	return f.createRelease(commit, title, changelog, prerelease, latest, content)
+}
+
+func (f *Forge) UploadReleaseAssets(_ context.Context, tag string, assets []forge.ReleaseAsset) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	release, ok := f.Releases[tag]
+	if !ok {
+		return fmt.Errorf("release %s does not exist", tag)
+	}
+
+	content, err := readAssets(assets)
+	if err != nil {
+		return err
+	}
+
+	if release.Assets == nil {
+		release.Assets = map[string][]byte{}
+	}
+	maps.Copy(release.Assets, content)
+
+	return nil
+}
+
+func (f *Forge) createRelease(commit git.Commit, title, changelog string, prerelease, latest bool, assets map[string][]byte) error {
+	if _, exists := f.Releases[title]; exists {
+		return fmt.Errorf("release %s already exists", title)
+	}
+
+	if err := f.Remote.Tag(title, commit.Hash); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	f.Releases[title] = &Release{
+		Commit:     commit,
+		Title:      title,
+		Changelog:  changelog,
+		Prerelease: prerelease,
+		Latest:     latest,
+		Assets:     assets,
+	}
+
+	return nil
+}
+
+func readAssets(assets []forge.ReleaseAsset) (map[string][]byte, error) {
+	content := make(map[string][]byte, len(assets))
+	for _, asset := range assets {
+		data, err := os.ReadFile(asset.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release asset %s: %w", asset.Name, err)
+		}
+
+		content[asset.Name] = data
+	}
+
+	return content, nil
+}
+
+// MergePullRequest squash merges the open pull request into the base branch, like a user clicking the button in the
+// web interface would.
+func (f *Forge) MergePullRequest(id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pr, err := f.pullRequest(id)
+	if err != nil {
+		return err
+	}
+	if pr.State != PRStateOpen {
+		return fmt.Errorf("pull request %d is not open", id)
+	}
+
+	hash, err := f.Remote.SquashMerge(f.options.BaseBranch, pr.Head, pr.Title)
+	if err != nil {
+		return err
+	}
+
+	pr.State = PRStateMerged
+	pr.ReleaseCommit = &git.Commit{Hash: hash, Message: pr.Title}
+	f.CommitPullRequests[hash] = &pr.PullRequest
+
+	return nil
+}
+
+// OpenPullRequests returns all open pull requests, ordered by ID.
+func (f *Forge) OpenPullRequests() []*PullRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var prs []*PullRequest
+	for _, id := range slices.Sorted(maps.Keys(f.PullRequests)) {
+		if pr := f.PullRequests[id]; pr.State == PRStateOpen {
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs
+}
+
+func (f *Forge) pullRequest(id int64) (*PullRequest, error) {
+	pr, ok := f.PullRequests[id]
+	if !ok {
+		return nil, fmt.Errorf("pull request %d not found", id)
+	}
+
+	return pr, nil
+}
+
+func (f *Forge) checkLabelsExist(labels []releasepr.Label) error {
+	for _, label := range labels {
+		if _, ok := f.Labels[label.Name]; !ok {
+			return fmt.Errorf("label %q does not exist", label.Name)
+		}
+	}
+
+	return nil
+}
+
+// releasePullRequest returns a copy, so that changes made by the caller are not visible in the Forge. Like the real
+// forges, only the names of the labels are returned.
+func (pr *PullRequest) releasePullRequest() *releasepr.ReleasePullRequest {
+	c := copyReleasePullRequest(&pr.ReleasePullRequest)
+	for i, label := range c.Labels {
+		c.Labels[i] = releasepr.LabelFromName(label.Name)
+	}
+
+	return c
+}
+
+func copyReleasePullRequest(pr *releasepr.ReleasePullRequest) *releasepr.ReleasePullRequest {
+	c := *pr
+	c.Labels = slices.Clone(pr.Labels)
+	if pr.ReleaseCommit != nil {
+		commit := *pr.ReleaseCommit
+		c.ReleaseCommit = &commit
+	}
+
+	return &c
+}
+
+type Options struct {
+	forge.Options
+
+	Author git.Author
+}
+
+// New creates a Forge with a new Remote named after Options.Repository.
+func New(log *slog.Logger, options *Options) (*Forge, error) {
+	if options.BaseBranch == "" {
+		options.BaseBranch = "main"
+	}
+	if options.Author.Name == "" {
+		options.Author = git.Author{Name: "releaser-pleaser", Email: "releaser-pleaser@example.com"}
+	}
+
+	remote, err := NewRemote(options.Repository, options.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Forge{
+		options: options,
+		log:     log.With("forge", "fake"),
+
+		Remote: remote,
+
+		Labels:             map[string]releasepr.Label{},
+		PullRequests:       map[int64]*PullRequest{},
+		Releases:           map[string]*Release{},
+		CommitPullRequests: map[string]*git.PullRequest{},
+	}
+
+	return f, nil
+}
@@ -0,0 +1,317 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// Scheme is the URL scheme of the in-process git transport. It is registered with go-git when this package is
+	// imported, clone URLs look like `fake://remote/<name>`.
+	Scheme = "fake"
+
+	initialCommitMessage = "chore: init"
+)
+
+var (
+	remotesMu sync.Mutex
+	remotes   = map[string]*Remote{}
+
+	// ErrProtectedBranch is returned to pushes that update a branch protected with Remote.Protect.
+	ErrProtectedBranch = errors.New("branch is protected")
+)
+
+func init() {
+	client.InstallProtocol(Scheme, server.NewClient(loader{}))
+}
+
+// loader looks up the storage of the Remote by the path of the endpoint.
+type loader struct{}
+
+func (loader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	remotesMu.Lock()
+	defer remotesMu.Unlock()
+
+	r, ok := remotes[ep.Path]
+	if !ok {
+		return nil, transport.ErrRepositoryNotFound
+	}
+
+	if len(r.protected) == 0 {
+		return r.repo.Storer, nil
+	}
+
+	return protectedStorer{Storer: r.repo.Storer, protected: r.protected}, nil
+}
+
+// protectedStorer rejects reference updates of the protected branches.
+type protectedStorer struct {
+	storer.Storer
+
+	protected map[plumbing.ReferenceName]bool
+}
+
+func (s protectedStorer) SetReference(ref *plumbing.Reference) error {
+	if s.protected[ref.Name()] {
+		return ErrProtectedBranch
+	}
+
+	return s.Storer.SetReference(ref)
+}
+
+func (s protectedStorer) RemoveReference(name plumbing.ReferenceName) error {
+	if s.protected[name] {
+		return ErrProtectedBranch
+	}
+
+	return s.Storer.RemoveReference(name)
+}
+
+// Remote is an in-memory git repository that can be cloned from and pushed to through the URL returned by URL.
+// It has a worktree, which is only used to create commits through Commit.
+type Remote struct {
+	name          string
+	defaultBranch string
+
+	repo *git.Repository
+	// protected are the branches that can not be pushed to, guarded by remotesMu.
+	protected map[plumbing.ReferenceName]bool
+}
+
+// NewRemote creates a new Remote with an initial commit on defaultBranch. The name needs to be unique in the process.
+func NewRemote(name, defaultBranch string) (*Remote, error) {
+	repo, err := git.InitWithOptions(memory.NewStorage(), memfs.New(), git.InitOptions{
+		DefaultBranch: plumbing.NewBranchReferenceName(defaultBranch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-memory repository: %w", err)
+	}
+
+	r := &Remote{
+		name:          name,
+		defaultBranch: defaultBranch,
+		repo:          repo,
+	}
+
+	if _, err = r.Commit(defaultBranch, initialCommitMessage, map[string]string{"README.md": "# " + name}); err != nil {
+		return nil, err
+	}
+
+	remotesMu.Lock()
+	defer remotesMu.Unlock()
+
+	if _, exists := remotes["/"+name]; exists {
+		return nil, fmt.Errorf("remote %q already exists", name)
+	}
+	remotes["/"+name] = r
+
+	return r, nil
+}
+
+// URL returns the clone URL of the Remote.
+func (r *Remote) URL() string {
+	return fmt.Sprintf("%s://remote/%s", Scheme, r.name)
+}
+
+// Repository returns the underlying go-git repository.
+func (r *Remote) Repository() *git.Repository {
+	return r.repo
+}
+
+// Protect rejects all pushes to branch, like a protected branch on a forge. Commit still works.
+func (r *Remote) Protect(branch string) {
+	remotesMu.Lock()
+	defer remotesMu.Unlock()
+
+	if r.protected == nil {
+		r.protected = map[plumbing.ReferenceName]bool{}
+	}
+	r.protected[plumbing.NewBranchReferenceName(branch)] = true
+}
+
+// Close removes the Remote from the in-process transport.
+func (r *Remote) Close() {
+	remotesMu.Lock()
+	defer remotesMu.Unlock()
+
+	delete(remotes, "/"+r.name)
+}
+
+// Commit creates a new commit on branch with the files added or replaced. The branch is created from the default
+// branch if it does not exist yet. It returns the hash of the commit.
+func (r *Remote) Commit(branch, message string, files map[string]string) (string, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	branchRef := plumbing.NewBranchReferenceName(branch)
+
+	checkoutOptions := &git.CheckoutOptions{Branch: branchRef, Force: true}
+	if _, err = r.repo.Reference(branchRef, false); errors.Is(err, plumbing.ErrReferenceNotFound) {
+		if head, err := r.repo.Reference(plumbing.NewBranchReferenceName(r.defaultBranch), false); err == nil {
+			checkoutOptions.Create = true
+			checkoutOptions.Hash = head.Hash()
+		} else {
+			// Empty repository, the first commit creates the branch.
+			checkoutOptions = nil
+		}
+	}
+
+	if checkoutOptions != nil {
+		if err = worktree.Checkout(checkoutOptions); err != nil {
+			return "", fmt.Errorf("failed to check out branch %s: %w", branch, err)
+		}
+
+		defer func() {
+			_ = worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(r.defaultBranch), Force: true})
+		}()
+	}
+
+	for path, content := range files {
+		file, err := worktree.Filesystem.Create(path)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = file.Write([]byte(content))
+		_ = file.Close()
+		if err != nil {
+			return "", err
+		}
+
+		if _, err = worktree.Add(path); err != nil {
+			return "", err
+		}
+	}
+
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            signature(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return hash.String(), nil
+}
+
+// Tag creates a lightweight tag pointing at the commit.
+func (r *Remote) Tag(name, hash string) error {
+	_, err := r.repo.CreateTag(name, plumbing.NewHash(hash), nil)
+	return err
+}
+
+// Head returns the hash of the commit at the tip of branch.
+func (r *Remote) Head(branch string) (string, error) {
+	ref, err := r.repo.Reference(plumbing.NewBranchReferenceName(branch), false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+
+	return ref.Hash().String(), nil
+}
+
+// File returns the content of the file on branch. If the file does not exist, it returns nil.
+func (r *Remote) File(branch, path string) ([]byte, error) {
+	hash, err := r.Head(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := r.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := commit.File(path)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	return io.ReadAll(reader)
+}
+
+// SquashMerge creates a single commit on base with the content of head and moves base to it. It fails if head is not
+// based on the current tip of base, which would require a real merge.
+func (r *Remote) SquashMerge(base, head, message string) (string, error) {
+	baseHash, err := r.Head(base)
+	if err != nil {
+		return "", err
+	}
+	headHash, err := r.Head(head)
+	if err != nil {
+		return "", err
+	}
+
+	baseCommit, err := r.repo.CommitObject(plumbing.NewHash(baseHash))
+	if err != nil {
+		return "", err
+	}
+	headCommit, err := r.repo.CommitObject(plumbing.NewHash(headHash))
+	if err != nil {
+		return "", err
+	}
+
+	isAncestor, err := baseCommit.IsAncestor(headCommit)
+	if err != nil {
+		return "", err
+	}
+	if !isAncestor {
+		return "", fmt.Errorf("branch %s is not based on the tip of %s", head, base)
+	}
+
+	squashed := &object.Commit{
+		Author:       *signature(),
+		Committer:    *signature(),
+		Message:      message,
+		TreeHash:     headCommit.TreeHash,
+		ParentHashes: []plumbing.Hash{baseCommit.Hash},
+	}
+
+	obj := r.repo.Storer.NewEncodedObject()
+	if err = squashed.Encode(obj); err != nil {
+		return "", err
+	}
+
+	hash, err := r.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return "", err
+	}
+
+	if err = r.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(base), hash)); err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+func signature() *object.Signature {
+	return &object.Signature{
+		Name:  "releaser-pleaser",
+		Email: "releaser-pleaser@example.com",
+		When:  time.Now(),
+	}
+}
@@ -2,6 +2,7 @@ package forge
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-git/go-git/v5/plumbing/transport"
 
@@ -19,12 +20,16 @@ type Forge interface {
 
 	GitAuth() transport.AuthMethod
 
+	// ReadFile returns the content of the file at path on Options.BaseBranch. If the file does not exist, it returns nil.
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+
 	// CommitAuthor returns the git author used for the release commit. It should be the user whose token is used to talk to the API.
 	CommitAuthor(context.Context) (git.Author, error)
 
 	// LatestTags returns the last stable tag created on the main branch. If there is a more recent pre-release tag,
-	// that is also returned. If no tag is found, it returns nil.
-	LatestTags(context.Context) (git.Releases, error)
+	// that is also returned. If no tag is found, it returns nil. Only tags that are releases according to releaseTags
+	// are considered, use git.LatestReleases to pick them.
+	LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error)
 
 	// CommitsSince returns all commits to main branch after the Tag. The tag can be `nil`, in which case this
 	// function should return all commits.
@@ -64,6 +69,37 @@ type Forge interface {
 	CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, prerelease, latest bool) error
 }
 
+// PullRequestMerger is implemented by forges that merge the release pull request into the base branch when it is
+// created. The release is then created in the same run.
+type PullRequestMerger interface {
+	// MergesPullRequests reports if Forge.CreatePullRequest merges the pull request.
+	MergesPullRequests() bool
+}
+
+// ErrReleaseAssetsUnsupported is returned if release assets are configured for a Forge that does not implement
+// AssetUploader.
+var ErrReleaseAssetsUnsupported = errors.New("the forge does not support release assets")
+
+// ReleaseAsset is a local file that is attached to a release.
+type ReleaseAsset struct {
+	// Name is the file name of the asset in the release.
+	Name string
+	// Path is the location of the file on the local disk.
+	Path string
+}
+
+// AssetUploader is implemented by forges that can attach files to releases. Callers need to check if the Forge
+// implements it before using release assets.
+type AssetUploader interface {
+	// CreateReleaseWithAssets creates a release like Forge.CreateRelease and attaches the assets. The release is only
+	// published after all assets were uploaded, so that it never appears without them.
+	CreateReleaseWithAssets(ctx context.Context, commit git.Commit, title, changelog string, prerelease, latest bool, assets []ReleaseAsset) error
+
+	// UploadReleaseAssets attaches the assets to the existing release of the tag. Existing assets with the same name
+	// are replaced.
+	UploadReleaseAssets(ctx context.Context, tag string, assets []ReleaseAsset) error
+}
+
 type Options struct {
 	Repository string
 	BaseBranch string
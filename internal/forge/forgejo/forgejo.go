@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	nethttp "net/http"
+	"os"
 	"slices"
 	"strings"
 
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
-	"github.com/blang/semver/v4"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 
@@ -20,7 +21,10 @@ import (
 
 const ()
 
-var _ forge.Forge = &Forgejo{}
+var (
+	_ forge.Forge         = &Forgejo{}
+	_ forge.AssetUploader = &Forgejo{}
+)
 
 type Forgejo struct {
 	options *Options
@@ -60,6 +64,20 @@ func (f *Forgejo) GitAuth() transport.AuthMethod {
 	}
 }
 
+func (f *Forgejo) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	f.log.DebugContext(ctx, "reading file from repository", "file.path", path)
+
+	content, resp, err := f.client.GetFile(f.options.Owner, f.options.Repo, f.options.BaseBranch, path)
+	if err != nil {
+		if resp != nil && resp.StatusCode == nethttp.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return content, nil
+}
+
 func (f *Forgejo) CommitAuthor(ctx context.Context) (git.Author, error) {
 	f.log.DebugContext(ctx, "getting commit author from current token user")
 
@@ -80,7 +98,7 @@ func (f *Forgejo) CommitAuthor(ctx context.Context) (git.Author, error) {
 	}, nil
 }
 
-func (f *Forgejo) LatestTags(ctx context.Context) (git.Releases, error) {
+func (f *Forgejo) LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error) {
 	f.log.DebugContext(ctx, "listing all tags in forgejo repository")
 
 	tags, err := all(func(listOptions forgejo.ListOptions) ([]*forgejo.Tag, *forgejo.Response, error) {
@@ -92,37 +110,15 @@ func (f *Forgejo) LatestTags(ctx context.Context) (git.Releases, error) {
 		return git.Releases{}, err
 	}
 
-	var releases git.Releases
-
+	gitTags := make([]git.Tag, 0, len(tags))
 	for _, fTag := range tags {
-		tag := &git.Tag{
+		gitTags = append(gitTags, git.Tag{
 			Hash: fTag.Commit.SHA,
 			Name: fTag.Name,
-		}
-
-		version, err := semver.Parse(strings.TrimPrefix(tag.Name, "v"))
-		if err != nil {
-			f.log.WarnContext(
-				ctx, "unable to parse tag as semver, skipping",
-				"tag.name", tag.Name,
-				"tag.hash", tag.Hash,
-				"error", err,
-			)
-			continue
-		}
-
-		if releases.Latest == nil {
-			releases.Latest = tag
-		}
-		if len(version.Pre) == 0 {
-			// Stable version tag
-			// We return once we have found the latest stable tag, not needed to look at every single tag.
-			releases.Stable = tag
-			break
-		}
+		})
 	}
 
-	return releases, nil
+	return git.LatestReleases(gitTags, releaseTags), nil
 }
 
 func (f *Forgejo) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit, error) {
@@ -145,6 +141,9 @@ func (f *Forgejo) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit,
 			URL:     f.CommitURL(fCommit.SHA),
 			Message: fCommit.RepoCommit.Message,
 		}
+		if fCommit.RepoCommit.Author != nil {
+			commit.Author = git.Author{Name: fCommit.RepoCommit.Author.Name, Email: fCommit.RepoCommit.Author.Email}
+		}
 		commit.PullRequest, err = f.prForCommit(ctx, commit)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for commit pull request: %w", err)
@@ -426,32 +425,130 @@ func (f *Forgejo) PendingReleases(_ context.Context, pendingLabel releasepr.Labe
 			continue
 		}
 
+		// The API has no filter for the base branch. Release pull requests of other branches, eg. maintenance
+		// branches, are released by the run on that branch.
+		if pr.Base == nil || pr.Base.Ref != f.options.BaseBranch {
+			continue
+		}
+
 		prs = append(prs, forgejoPRToReleasePullRequest(pr))
 	}
 
 	return prs, nil
 }
 
-func (f *Forgejo) CreateRelease(_ context.Context, commit git.Commit, title, changelog string, preRelease, latest bool) error {
-	// latest can not be set through the API
+// CreateRelease creates the release. Forgejo has no way to set the latest release, it is always the most recently
+// created release that is not a pre-release. Releases that should not be the latest are logged.
+func (f *Forgejo) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool) error {
+	_, err := f.createRelease(ctx, commit, title, changelog, preRelease, latest, false)
+	return err
+}
+
+// CreateReleaseWithAssets creates a draft release, uploads the assets and publishes the release afterwards. Forgejo
+// only creates the tag when the draft is published. If an upload fails, the draft is deleted again so that the next
+// run can start over.
+func (f *Forgejo) CreateReleaseWithAssets(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool, assets []forge.ReleaseAsset) error {
+	draft, err := f.createRelease(ctx, commit, title, changelog, preRelease, latest, true)
+	if err != nil {
+		return err
+	}
 
-	_, _, err := f.client.CreateRelease(
+	err = f.uploadReleaseAssets(ctx, draft.ID, assets)
+	if err != nil {
+		_, deleteErr := f.client.DeleteRelease(f.options.Owner, f.options.Repo, draft.ID)
+		if deleteErr != nil {
+			f.log.WarnContext(ctx, "failed to delete draft release", "release.id", draft.ID, "err", deleteErr)
+		}
+
+		return err
+	}
+
+	_, _, err = f.client.EditRelease(f.options.Owner, f.options.Repo, draft.ID, forgejo.EditReleaseOption{
+		IsDraft: pointer.Pointer(false),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish release: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Forgejo) createRelease(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest, draft bool) (*forgejo.Release, error) {
+	// Forgejo has no option to choose the latest release, it is always the most recently created stable release.
+	// Creating the release would replace the latest release with an older version.
+	if !preRelease && !latest {
+		return nil, fmt.Errorf("release %s is not the highest version, but forgejo always marks the most recent stable release as latest", title)
+	}
+
+	release, _, err := f.client.CreateRelease(
 		f.options.Owner, f.options.Repo,
 		forgejo.CreateReleaseOption{
 			TagName:      title,
 			Target:       commit.Hash,
 			Title:        title,
 			Note:         changelog,
+			IsDraft:      draft,
 			IsPrerelease: preRelease,
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return release, nil
+}
+
+func (f *Forgejo) UploadReleaseAssets(ctx context.Context, tag string, assets []forge.ReleaseAsset) error {
+	release, resp, err := f.client.GetReleaseByTag(f.options.Owner, f.options.Repo, tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == nethttp.StatusNotFound {
+			return fmt.Errorf("release %s does not exist", tag)
+		}
+		return fmt.Errorf("failed to get release: %w", err)
+	}
+
+	return f.uploadReleaseAssets(ctx, release.ID, assets)
+}
+
+func (f *Forgejo) uploadReleaseAssets(ctx context.Context, releaseID int64, assets []forge.ReleaseAsset) error {
+	existing, err := all(func(listOptions forgejo.ListOptions) ([]*forgejo.Attachment, *forgejo.Response, error) {
+		return f.client.ListReleaseAttachments(f.options.Owner, f.options.Repo, releaseID, forgejo.ListReleaseAttachmentsOptions{
+			ListOptions: listOptions,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list release attachments: %w", err)
+	}
+
+	for _, asset := range assets {
+		if i := slices.IndexFunc(existing, func(a *forgejo.Attachment) bool { return a.Name == asset.Name }); i >= 0 {
+			_, err = f.client.DeleteReleaseAttachment(f.options.Owner, f.options.Repo, releaseID, existing[i].ID)
+			if err != nil {
+				return fmt.Errorf("failed to delete existing release attachment %s: %w", asset.Name, err)
+			}
+		}
+
+		err = f.uploadReleaseAsset(releaseID, asset)
+		if err != nil {
+			return fmt.Errorf("failed to upload release asset %s: %w", asset.Name, err)
+		}
+		f.log.DebugContext(ctx, "uploaded release asset", "asset.name", asset.Name)
 	}
 
 	return nil
 }
 
+func (f *Forgejo) uploadReleaseAsset(releaseID int64, asset forge.ReleaseAsset) error {
+	file, err := os.Open(asset.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, _, err = f.client.CreateReleaseAttachment(f.options.Owner, f.options.Repo, releaseID, file, asset.Name)
+	return err
+}
+
 func all[T any](f func(listOptions forgejo.ListOptions) ([]T, *forgejo.Response, error)) ([]T, error) {
 	results := make([]T, 0)
 	page := 1
@@ -472,22 +569,23 @@ func all[T any](f func(listOptions forgejo.ListOptions) ([]T, *forgejo.Response,
 }
 
 func forgejoPRToPullRequest(pr *forgejo.PullRequest) *git.PullRequest {
-	return &git.PullRequest{
+	gitPR := &git.PullRequest{
 		ID:          pr.Index,
 		Title:       pr.Title,
 		Description: pr.Body,
+		URL:         pr.HTMLURL,
+	}
+	if pr.Poster != nil {
+		gitPR.Author = pr.Poster.UserName
 	}
+
+	return gitPR
 }
 
 func forgejoPRToReleasePullRequest(pr *forgejo.PullRequest) *releasepr.ReleasePullRequest {
 	labels := make([]releasepr.Label, 0, len(pr.Labels))
 	for _, label := range pr.Labels {
-		labelName := label.Name
-		if i := slices.IndexFunc(releasepr.KnownLabels, func(label releasepr.Label) bool {
-			return label.Name == labelName
-		}); i >= 0 {
-			labels = append(labels, releasepr.KnownLabels[i])
-		}
+		labels = append(labels, releasepr.LabelFromName(label.Name))
 	}
 
 	var releaseCommit *git.Commit
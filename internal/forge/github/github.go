@@ -5,11 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	nethttp "net/http"
 	"os"
 	"slices"
 	"strings"
 
-	"github.com/blang/semver/v4"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/google/go-github/v86/github"
@@ -36,7 +36,10 @@ var (
 	}
 )
 
-var _ forge.Forge = &GitHub{}
+var (
+	_ forge.Forge         = &GitHub{}
+	_ forge.AssetUploader = &GitHub{}
+)
 
 type GitHub struct {
 	options *Options
@@ -76,6 +79,31 @@ func (g *GitHub) GitAuth() transport.AuthMethod {
 	}
 }
 
+func (g *GitHub) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	g.log.DebugContext(ctx, "reading file from repository", "file.path", path)
+
+	file, _, resp, err := g.client.Repositories.GetContents(
+		ctx, g.options.Owner, g.options.Repo,
+		path, &github.RepositoryContentGetOptions{Ref: g.options.BaseBranch},
+	)
+	if err != nil {
+		if resp != nil && resp.StatusCode == nethttp.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("path %q is not a file", path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
+
 func (g *GitHub) CommitAuthor(ctx context.Context) (git.Author, error) {
 	g.log.DebugContext(ctx, "getting commit author from current token user")
 
@@ -92,7 +120,7 @@ func (g *GitHub) CommitAuthor(ctx context.Context) (git.Author, error) {
 	}, nil
 }
 
-func (g *GitHub) LatestTags(ctx context.Context) (git.Releases, error) {
+func (g *GitHub) LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error) {
 	g.log.DebugContext(ctx, "listing all tags in github repository")
 
 	tags, err := all(func(listOptions github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
@@ -105,37 +133,15 @@ func (g *GitHub) LatestTags(ctx context.Context) (git.Releases, error) {
 		return git.Releases{}, err
 	}
 
-	var releases git.Releases
-
+	gitTags := make([]git.Tag, 0, len(tags))
 	for _, ghTag := range tags {
-		tag := &git.Tag{
+		gitTags = append(gitTags, git.Tag{
 			Hash: ghTag.GetCommit().GetSHA(),
 			Name: ghTag.GetName(),
-		}
-
-		version, err := semver.Parse(strings.TrimPrefix(tag.Name, "v"))
-		if err != nil {
-			g.log.WarnContext(
-				ctx, "unable to parse tag as semver, skipping",
-				"tag.name", tag.Name,
-				"tag.hash", tag.Hash,
-				"error", err,
-			)
-			continue
-		}
-
-		if releases.Latest == nil {
-			releases.Latest = tag
-		}
-		if len(version.Pre) == 0 {
-			// Stable version tag
-			// We return once we have found the latest stable tag, not needed to look at every single tag.
-			releases.Stable = tag
-			break
-		}
+		})
 	}
 
-	return releases, nil
+	return git.LatestReleases(gitTags, releaseTags), nil
 }
 
 func (g *GitHub) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit, error) {
@@ -157,6 +163,10 @@ func (g *GitHub) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit,
 			Hash:    ghCommit.GetSHA(),
 			URL:     g.CommitURL(ghCommit.GetSHA()),
 			Message: ghCommit.GetCommit().GetMessage(),
+			Author: git.Author{
+				Name:  ghCommit.GetCommit().GetAuthor().GetName(),
+				Email: ghCommit.GetCommit().GetAuthor().GetEmail(),
+			},
 		}
 		commit.PullRequest, err = g.prForCommit(ctx, commit)
 		if err != nil {
@@ -440,12 +450,6 @@ func (g *GitHub) PendingReleases(ctx context.Context, pendingLabel releasepr.Lab
 }
 
 func (g *GitHub) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool) error {
-	makeLatest := ""
-	if latest {
-		makeLatest = "true"
-	} else {
-		makeLatest = "false"
-	}
 	_, _, err := g.client.Repositories.CreateRelease(
 		ctx, g.options.Owner, g.options.Repo,
 		&github.RepositoryRelease{
@@ -454,7 +458,7 @@ func (g *GitHub) CreateRelease(ctx context.Context, commit git.Commit, title, ch
 			Name:            &title,
 			Body:            &changelog,
 			Prerelease:      &preRelease,
-			MakeLatest:      &makeLatest,
+			MakeLatest:      pointer.Pointer(makeLatest(latest)),
 		},
 	)
 	if err != nil {
@@ -464,6 +468,108 @@ func (g *GitHub) CreateRelease(ctx context.Context, commit git.Commit, title, ch
 	return nil
 }
 
+// CreateReleaseWithAssets creates a draft release, uploads the assets and publishes the release afterwards. GitHub
+// only creates the tag when the draft is published. If an upload fails, the draft is deleted again so that the next
+// run can start over.
+func (g *GitHub) CreateReleaseWithAssets(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool, assets []forge.ReleaseAsset) error {
+	draft, _, err := g.client.Repositories.CreateRelease(
+		ctx, g.options.Owner, g.options.Repo,
+		&github.RepositoryRelease{
+			TagName:         &title,
+			TargetCommitish: &commit.Hash,
+			Name:            &title,
+			Body:            &changelog,
+			Prerelease:      &preRelease,
+			Draft:           pointer.Pointer(true),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = g.uploadReleaseAssets(ctx, draft.GetID(), assets)
+	if err != nil {
+		_, deleteErr := g.client.Repositories.DeleteRelease(ctx, g.options.Owner, g.options.Repo, draft.GetID())
+		if deleteErr != nil {
+			g.log.WarnContext(ctx, "failed to delete draft release", "release.id", draft.GetID(), "err", deleteErr)
+		}
+
+		return err
+	}
+
+	_, _, err = g.client.Repositories.EditRelease(
+		ctx, g.options.Owner, g.options.Repo, draft.GetID(),
+		&github.RepositoryRelease{
+			Draft:      pointer.Pointer(false),
+			MakeLatest: pointer.Pointer(makeLatest(latest)),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish release: %w", err)
+	}
+
+	return nil
+}
+
+func (g *GitHub) UploadReleaseAssets(ctx context.Context, tag string, assets []forge.ReleaseAsset) error {
+	release, resp, err := g.client.Repositories.GetReleaseByTag(ctx, g.options.Owner, g.options.Repo, tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == nethttp.StatusNotFound {
+			return fmt.Errorf("release %s does not exist", tag)
+		}
+		return fmt.Errorf("failed to get release: %w", err)
+	}
+
+	return g.uploadReleaseAssets(ctx, release.GetID(), assets)
+}
+
+func (g *GitHub) uploadReleaseAssets(ctx context.Context, releaseID int64, assets []forge.ReleaseAsset) error {
+	existing, err := all(func(listOptions github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+		return g.client.Repositories.ListReleaseAssets(ctx, g.options.Owner, g.options.Repo, releaseID, &listOptions)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list release assets: %w", err)
+	}
+
+	for _, asset := range assets {
+		// GitHub rejects uploads with the name of an existing asset
+		if i := slices.IndexFunc(existing, func(a *github.ReleaseAsset) bool { return a.GetName() == asset.Name }); i >= 0 {
+			_, err = g.client.Repositories.DeleteReleaseAsset(ctx, g.options.Owner, g.options.Repo, existing[i].GetID())
+			if err != nil {
+				return fmt.Errorf("failed to delete existing release asset %s: %w", asset.Name, err)
+			}
+		}
+
+		err = g.uploadReleaseAsset(ctx, releaseID, asset)
+		if err != nil {
+			return fmt.Errorf("failed to upload release asset %s: %w", asset.Name, err)
+		}
+		g.log.DebugContext(ctx, "uploaded release asset", "asset.name", asset.Name)
+	}
+
+	return nil
+}
+
+func (g *GitHub) uploadReleaseAsset(ctx context.Context, releaseID int64, asset forge.ReleaseAsset) error {
+	file, err := os.Open(asset.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, _, err = g.client.Repositories.UploadReleaseAsset(ctx, g.options.Owner, g.options.Repo, releaseID, &github.UploadOptions{Name: asset.Name}, file)
+	return err
+}
+
+// makeLatest returns the value of the make_latest field of the GitHub API.
+func makeLatest(latest bool) string {
+	if latest {
+		return "true"
+	}
+
+	return "false"
+}
+
 func all[T any](f func(listOptions github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
 	results := make([]T, 0)
 	page := 1
@@ -488,18 +594,15 @@ func gitHubPRToPullRequest(pr *github.PullRequest) *git.PullRequest {
 		ID:          int64(pr.GetNumber()),
 		Title:       pr.GetTitle(),
 		Description: pr.GetBody(),
+		URL:         pr.GetHTMLURL(),
+		Author:      pr.GetUser().GetLogin(),
 	}
 }
 
 func gitHubPRToReleasePullRequest(pr *github.PullRequest) *releasepr.ReleasePullRequest {
 	labels := make([]releasepr.Label, 0, len(pr.Labels))
 	for _, label := range pr.Labels {
-		labelName := label.GetName()
-		if i := slices.IndexFunc(releasepr.KnownLabels, func(label releasepr.Label) bool {
-			return label.Name == labelName
-		}); i >= 0 {
-			labels = append(labels, releasepr.KnownLabels[i])
-		}
+		labels = append(labels, releasepr.LabelFromName(label.GetName()))
 	}
 
 	var releaseCommit *git.Commit
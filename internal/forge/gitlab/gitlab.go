@@ -2,13 +2,16 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	nethttp "net/http"
 	"os"
+	"path"
 	"slices"
 	"strings"
+	"time"
 
-	"github.com/blang/semver/v4"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
@@ -34,6 +37,11 @@ const (
 	EnvProjectPath = "CI_PROJECT_PATH"
 )
 
+var (
+	_ forge.Forge         = &GitLab{}
+	_ forge.AssetUploader = &GitLab{}
+)
+
 type GitLab struct {
 	options *Options
 
@@ -77,6 +85,22 @@ func (g *GitLab) GitAuth() transport.AuthMethod {
 	}
 }
 
+func (g *GitLab) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	g.log.DebugContext(ctx, "reading file from repository", "file.path", path)
+
+	content, resp, err := g.client.RepositoryFiles.GetRawFile(g.options.Path, path, &gitlab.GetRawFileOptions{
+		Ref: pointer.Pointer(g.options.BaseBranch),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == nethttp.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return content, nil
+}
+
 func (g *GitLab) CommitAuthor(ctx context.Context) (git.Author, error) {
 	g.log.DebugContext(ctx, "getting commit author from current token user")
 
@@ -93,7 +117,7 @@ func (g *GitLab) CommitAuthor(ctx context.Context) (git.Author, error) {
 	}, nil
 }
 
-func (g *GitLab) LatestTags(ctx context.Context) (git.Releases, error) {
+func (g *GitLab) LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error) {
 	g.log.DebugContext(ctx, "listing all tags in gitlab repository")
 
 	tags, err := all(func(listOptions gitlab.ListOptions) ([]*gitlab.Tag, *gitlab.Response, error) {
@@ -106,37 +130,15 @@ func (g *GitLab) LatestTags(ctx context.Context) (git.Releases, error) {
 		return git.Releases{}, err
 	}
 
-	var releases git.Releases
+	gitTags := make([]git.Tag, 0, len(tags))
 	for _, glTag := range tags {
-		tag := &git.Tag{
+		gitTags = append(gitTags, git.Tag{
 			Hash: glTag.Commit.ID,
 			Name: glTag.Name,
-		}
-
-		version, err := semver.Parse(strings.TrimPrefix(tag.Name, "v"))
-		if err != nil {
-			g.log.WarnContext(
-				ctx, "unable to parse tag as semver, skipping",
-				"tag.name", tag.Name,
-				"tag.hash", tag.Hash,
-				"error", err,
-			)
-			continue
-		}
-
-		if releases.Latest == nil {
-			releases.Latest = tag
-		}
-		if len(version.Pre) == 0 {
-			// Stable version tag
-			// We return once we have found the latest stable tag, not needed to look at every single tag.
-			releases.Stable = tag
-			break
-		}
-
+		})
 	}
 
-	return releases, nil
+	return git.LatestReleases(gitTags, releaseTags), nil
 }
 
 func (g *GitLab) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit, error) {
@@ -170,6 +172,7 @@ func (g *GitLab) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit,
 			Hash:    ghCommit.ID,
 			URL:     g.CommitURL(ghCommit.ID),
 			Message: ghCommit.Message,
+			Author:  git.Author{Name: ghCommit.AuthorName, Email: ghCommit.AuthorEmail},
 		}
 		commit.PullRequest, err = g.prForCommit(ctx, commit)
 		if err != nil {
@@ -370,20 +373,154 @@ func (g *GitLab) PendingReleases(ctx context.Context, pendingLabel releasepr.Lab
 	return prs, nil
 }
 
-func (g *GitLab) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, _, _ bool) error {
-	_, _, err := g.client.Releases.CreateRelease(g.options.Path, &gitlab.CreateReleaseOptions{
+// CreateRelease creates the release. GitLab has no flags for pre-releases or the latest release, the latest release is
+// the one with the most recent release date. Pre-releases and releases that should not be the latest are therefore
+// dated one second before the current latest release.
+func (g *GitLab) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool) error {
+	return g.createRelease(ctx, commit, title, changelog, preRelease, latest, nil)
+}
+
+// CreateReleaseWithAssets uploads the assets to the generic package registry of the project and creates the release
+// with links to the package files afterwards. GitLab has no draft releases, but this way the release never appears
+// without its assets.
+func (g *GitLab) CreateReleaseWithAssets(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool, assets []forge.ReleaseAsset) error {
+	links, err := g.uploadPackageFiles(ctx, title, assets)
+	if err != nil {
+		return err
+	}
+
+	return g.createRelease(ctx, commit, title, changelog, preRelease, latest, links)
+}
+
+func (g *GitLab) createRelease(ctx context.Context, commit git.Commit, title, changelog string, preRelease, latest bool, links []*gitlab.ReleaseAssetLinkOptions) error {
+	opts := &gitlab.CreateReleaseOptions{
 		Name:        &title,
 		TagName:     &title,
 		Description: &changelog,
 		Ref:         &commit.Hash,
-	}, gitlab.WithContext(ctx))
+	}
+
+	if len(links) > 0 {
+		opts.Assets = &gitlab.ReleaseAssetsOptions{Links: links}
+	}
+
+	// GitLab has no flags for pre-releases or the latest release, the latest release is the one with the most recent
+	// release date. Releases that must not replace the current latest release are dated one second before it. GitLab
+	// shows them as "historical release", which matches releases of older version lines. Several of these releases
+	// can end up with the same date, which only affects their order in the list of releases, not the latest release.
+	if preRelease || !latest {
+		latestRelease, resp, err := g.client.Releases.GetLatestRelease(g.options.Path, gitlab.WithContext(ctx))
+		if err != nil && (resp == nil || resp.StatusCode != nethttp.StatusNotFound) {
+			return fmt.Errorf("failed to get latest release: %w", err)
+		}
+
+		if latestRelease != nil && latestRelease.ReleasedAt != nil {
+			opts.ReleasedAt = pointer.Pointer(latestRelease.ReleasedAt.Add(-time.Second))
+		}
+	}
+
+	_, _, err := g.client.Releases.CreateRelease(g.options.Path, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UploadReleaseAssets uploads the assets to the generic package registry and links them in the release. Existing links
+// with the same name are pointed at the new package file.
+func (g *GitLab) UploadReleaseAssets(ctx context.Context, tag string, assets []forge.ReleaseAsset) error {
+	existing, err := all(func(listOptions gitlab.ListOptions) ([]*gitlab.ReleaseLink, *gitlab.Response, error) {
+		return g.client.ReleaseLinks.ListReleaseLinks(g.options.Path, tag, &gitlab.ListReleaseLinksOptions{
+			ListOptions: listOptions,
+		}, gitlab.WithContext(ctx))
+	})
+	if err != nil {
+		if errors.Is(err, gitlab.ErrNotFound) {
+			return fmt.Errorf("release %s does not exist", tag)
+		}
+		return fmt.Errorf("failed to list release links: %w", err)
+	}
+
+	links, err := g.uploadPackageFiles(ctx, tag, assets)
 	if err != nil {
 		return err
 	}
 
+	for _, link := range links {
+		if i := slices.IndexFunc(existing, func(l *gitlab.ReleaseLink) bool { return l.Name == *link.Name }); i >= 0 {
+			_, _, err = g.client.ReleaseLinks.UpdateReleaseLink(g.options.Path, tag, existing[i].ID, &gitlab.UpdateReleaseLinkOptions{
+				URL:      link.URL,
+				LinkType: link.LinkType,
+			}, gitlab.WithContext(ctx))
+		} else {
+			_, _, err = g.client.ReleaseLinks.CreateReleaseLink(g.options.Path, tag, &gitlab.CreateReleaseLinkOptions{
+				Name:     link.Name,
+				URL:      link.URL,
+				LinkType: link.LinkType,
+			}, gitlab.WithContext(ctx))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to link release asset %s: %w", *link.Name, err)
+		}
+	}
+
 	return nil
 }
 
+// uploadPackageFiles publishes the assets as files of a generic package named after the project, the version of the
+// package is the tag. It returns the links to the package files.
+func (g *GitLab) uploadPackageFiles(ctx context.Context, tag string, assets []forge.ReleaseAsset) ([]*gitlab.ReleaseAssetLinkOptions, error) {
+	packageName := sanitizePackageName(path.Base(g.options.Path))
+	packageVersion := sanitizePackageName(tag)
+
+	links := make([]*gitlab.ReleaseAssetLinkOptions, 0, len(assets))
+	for _, asset := range assets {
+		err := g.uploadPackageFile(ctx, packageName, packageVersion, asset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload release asset %s: %w", asset.Name, err)
+		}
+		g.log.DebugContext(ctx, "uploaded release asset", "asset.name", asset.Name, "package.name", packageName, "package.version", packageVersion)
+
+		packageURL, err := g.client.GenericPackages.FormatPackageURL(g.options.Path, packageName, packageVersion, asset.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		links = append(links, &gitlab.ReleaseAssetLinkOptions{
+			Name:     pointer.Pointer(asset.Name),
+			URL:      pointer.Pointer(g.client.BaseURL().JoinPath(packageURL).String()),
+			LinkType: pointer.Pointer(gitlab.PackageLinkType),
+		})
+	}
+
+	return links, nil
+}
+
+func (g *GitLab) uploadPackageFile(ctx context.Context, packageName, packageVersion string, asset forge.ReleaseAsset) error {
+	file, err := os.Open(asset.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, _, err = g.client.GenericPackages.PublishPackageFile(g.options.Path, packageName, packageVersion, asset.Name, file, nil, gitlab.WithContext(ctx))
+	return err
+}
+
+// sanitizePackageName replaces all characters that are not allowed in names and versions of generic packages, eg. the slash
+// in `api/v1.2.3`.
+func sanitizePackageName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-', r == '+':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
+
 func all[T any](f func(listOptions gitlab.ListOptions) ([]T, *gitlab.Response, error)) ([]T, error) {
 	results := make([]T, 0)
 	page := int64(1)
@@ -404,21 +541,23 @@ func all[T any](f func(listOptions gitlab.ListOptions) ([]T, *gitlab.Response, e
 }
 
 func gitlabMRToPullRequest(pr *gitlab.BasicMergeRequest) *git.PullRequest {
-	return &git.PullRequest{
+	gitPR := &git.PullRequest{
 		ID:          pr.IID,
 		Title:       pr.Title,
 		Description: pr.Description,
+		URL:         pr.WebURL,
+	}
+	if pr.Author != nil {
+		gitPR.Author = pr.Author.Username
 	}
+
+	return gitPR
 }
 
 func gitlabMRToReleasePullRequest(pr *gitlab.BasicMergeRequest) *releasepr.ReleasePullRequest {
 	labels := make([]releasepr.Label, 0, len(pr.Labels))
 	for _, labelName := range pr.Labels {
-		if i := slices.IndexFunc(releasepr.KnownLabels, func(label releasepr.Label) bool {
-			return label.Name == labelName
-		}); i >= 0 {
-			labels = append(labels, releasepr.KnownLabels[i])
-		}
+		labels = append(labels, releasepr.LabelFromName(labelName))
 	}
 
 	// Commit SHA is saved in either [MergeCommitSHA], [SquashCommitSHA] or [SHA] depending on which merge method was used.
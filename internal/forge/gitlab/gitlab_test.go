@@ -0,0 +1,101 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/pointer"
+)
+
+func TestGitLab_CreateRelease(t *testing.T) {
+	latestReleasedAt := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		preRelease bool
+		latest     bool
+		// latestRelease is the current latest release, nil if there is none.
+		latestRelease  *time.Time
+		wantReleasedAt *time.Time
+	}{
+		{
+			name:           "latest",
+			latest:         true,
+			latestRelease:  &latestReleasedAt,
+			wantReleasedAt: nil,
+		},
+		{
+			name:           "pre-release",
+			preRelease:     true,
+			latestRelease:  &latestReleasedAt,
+			wantReleasedAt: pointer.Pointer(latestReleasedAt.Add(-time.Second)),
+		},
+		{
+			name:           "older version line",
+			latest:         false,
+			latestRelease:  &latestReleasedAt,
+			wantReleasedAt: pointer.Pointer(latestReleasedAt.Add(-time.Second)),
+		},
+		{
+			name:           "first release",
+			preRelease:     true,
+			latestRelease:  nil,
+			wantReleasedAt: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]any
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/v4/projects/{project}/releases/permalink/latest", func(w http.ResponseWriter, _ *http.Request) {
+				if tt.latestRelease == nil {
+					http.Error(w, `{"message":"404 Not Found"}`, http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"tag_name": "v2.0.0", "released_at": tt.latestRelease})
+			})
+			mux.HandleFunc("POST /api/v4/projects/{project}/releases", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "foo/bar", r.PathValue("project"))
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				_ = json.NewEncoder(w).Encode(map[string]any{"tag_name": created["tag_name"]})
+			})
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			for _, env := range []string{EnvAPIURL, EnvAPIToken, EnvProjectURL, EnvProjectPath} {
+				t.Setenv(env, "")
+			}
+			g, err := New(slog.New(slog.DiscardHandler), &Options{
+				Path:   "foo/bar",
+				APIURL: server.URL + "/api/v4",
+			})
+			require.NoError(t, err)
+
+			err = g.CreateRelease(context.Background(), git.Commit{Hash: "abc123"}, "v1.9.3", "changelog", tt.preRelease, tt.latest)
+			require.NoError(t, err)
+
+			require.NotNil(t, created)
+			assert.Equal(t, "v1.9.3", created["tag_name"])
+			assert.Equal(t, "abc123", created["ref"])
+
+			if tt.wantReleasedAt == nil {
+				assert.NotContains(t, created, "released_at")
+			} else {
+				releasedAt, err := time.Parse(time.RFC3339, created["released_at"].(string))
+				require.NoError(t, err)
+				assert.True(t, tt.wantReleasedAt.Equal(releasedAt), "released_at %s, want %s", releasedAt, tt.wantReleasedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package plaingit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+)
+
+const (
+	// ModeBranch pushes the release branch and stops. The branch needs to be merged into the base branch manually, the
+	// release commit is tagged in the next run.
+	ModeBranch = "branch"
+	// ModeTag pushes the release commit directly to the base branch. It is tagged as a pending release in the same run.
+	ModeTag = "tag"
+
+	EnvAuthorName  = "GIT_AUTHOR_NAME"
+	EnvAuthorEmail = "GIT_AUTHOR_EMAIL"
+
+	defaultAuthorName  = "releaser-pleaser"
+	defaultAuthorEmail = "releaser-pleaser@localhost"
+)
+
+var (
+	_ forge.Forge             = &PlainGit{}
+	_ forge.PullRequestMerger = &PlainGit{}
+)
+
+// PlainGit is a forge.Forge that only talks to a git remote. There are no pull requests, labels or releases, the
+// release is an annotated tag on the release commit.
+type PlainGit struct {
+	options *Options
+
+	repo *git.Repository
+	log  *slog.Logger
+}
+
+func (p *PlainGit) RepoURL() string {
+	return p.options.URL
+}
+
+func (p *PlainGit) CloneURL() string {
+	return p.options.URL
+}
+
+// ReleaseURL returns an empty string, as plain git repositories have no web interface to link to.
+func (p *PlainGit) ReleaseURL(_ string) string {
+	return ""
+}
+
+func (p *PlainGit) PullRequestURL(_ int64) string {
+	return ""
+}
+
+func (p *PlainGit) CommitURL(_ string) string {
+	return ""
+}
+
+func (p *PlainGit) CompareURL(_, _ string) string {
+	return ""
+}
+
+func (p *PlainGit) GitAuth() transport.AuthMethod {
+	if p.options.APIToken == "" {
+		// Use the default authentication of the transport, eg. the SSH agent.
+		return nil
+	}
+
+	username := p.options.Username
+	if username == "" {
+		// Username just needs to be any non-blank value
+		username = "releaser-pleaser"
+	}
+
+	return &http.BasicAuth{
+		Username: username,
+		Password: p.options.APIToken,
+	}
+}
+
+// repository returns the clone of the remote, it is created on first use.
+func (p *PlainGit) repository(ctx context.Context) (*git.Repository, error) {
+	if p.repo != nil {
+		return p.repo, nil
+	}
+
+	p.log.DebugContext(ctx, "cloning repository", "clone.url", p.CloneURL())
+	repo, err := git.CloneRepo(ctx, p.log, p.CloneURL(), p.options.BaseBranch, p.GitAuth())
+	if err != nil {
+		return nil, err
+	}
+
+	p.repo = repo
+	return repo, nil
+}
+
+func (p *PlainGit) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	p.log.DebugContext(ctx, "reading file from repository", "file.path", path)
+
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.ReadFile(ctx, path)
+}
+
+func (p *PlainGit) CommitAuthor(_ context.Context) (git.Author, error) {
+	return p.options.Author, nil
+}
+
+func (p *PlainGit) LatestTags(ctx context.Context, releaseTags git.ReleaseTags) (git.Releases, error) {
+	p.log.DebugContext(ctx, "listing all tags in repository")
+
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return git.Releases{}, err
+	}
+
+	return repo.LatestTags(ctx, releaseTags)
+}
+
+func (p *PlainGit) CommitsSince(ctx context.Context, tag *git.Tag) ([]git.Commit, error) {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.CommitsSince(ctx, tag)
+}
+
+// EnsureLabelsExist does nothing, plain git repositories have no labels.
+func (p *PlainGit) EnsureLabelsExist(_ context.Context, _ []releasepr.Label) error {
+	return nil
+}
+
+// PullRequestForBranch returns the release branch as a pull request in ModeBranch, with the title and description from
+// the message of the release commit. In ModeTag it always returns nil, as the release branch is merged in the same run.
+func (p *PlainGit) PullRequestForBranch(ctx context.Context, branch string) (*releasepr.ReleasePullRequest, error) {
+	if p.options.Mode == ModeTag {
+		return nil, nil
+	}
+
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The release branch is pushed from another clone
+	if err = repo.Fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.RemoteBranchCommit(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil {
+		return nil, nil
+	}
+
+	pr := releasePullRequest(*commit)
+	pr.Head = branch
+	pr.Labels = []releasepr.Label{releasepr.LabelReleasePending}
+
+	return pr, nil
+}
+
+func (p *PlainGit) PullRequestByID(ctx context.Context, pr *releasepr.ReleasePullRequest) (*releasepr.ReleasePullRequest, error) {
+	return p.PullRequestForBranch(ctx, pr.Head)
+}
+
+// CreatePullRequest is called after the release branch was pushed. The description is added to the message of the
+// release commit, so that it is available for the tag message when the release is created in a later run. In ModeTag
+// the base branch is then fast-forwarded to the release branch, which is deleted afterwards. The release commit is
+// tagged by PendingReleases.
+func (p *PlainGit) CreatePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The release branch was pushed from another clone
+	if err = repo.Fetch(ctx); err != nil {
+		return err
+	}
+
+	if _, err = repo.RewordRemoteBranch(ctx, pr.Head, releaseCommitMessage(pr)); err != nil {
+		return fmt.Errorf("failed to add description to release commit: %w", err)
+	}
+
+	if p.options.Mode != ModeTag {
+		p.log.InfoContext(ctx, "pushed release branch, merge it to create the release", "branch.name", pr.Head, "branch.base", p.options.BaseBranch)
+		return nil
+	}
+
+	releaseCommit, err := repo.PushRemoteBranch(ctx, pr.Head, p.options.BaseBranch)
+	if err != nil {
+		return fmt.Errorf("failed to push release commit to %s: %w", p.options.BaseBranch, err)
+	}
+	p.log.InfoContext(ctx, "pushed release commit", "commit.hash", releaseCommit.Hash, "branch.name", p.options.BaseBranch)
+
+	if err = repo.DeleteRemoteBranch(ctx, pr.Head); err != nil {
+		return fmt.Errorf("failed to delete release branch: %w", err)
+	}
+
+	// The clone does not contain the release commit on the base branch yet, it is cloned again on the next read.
+	p.repo = nil
+
+	return nil
+}
+
+// MergesPullRequests is true in ModeTag, as CreatePullRequest pushes the release commit to the base branch.
+func (p *PlainGit) MergesPullRequests() bool {
+	return p.options.Mode == ModeTag
+}
+
+// UpdatePullRequest updates the message of the release commit on the release branch, if the title or description
+// changed or the release commit was pushed again. It is only called in ModeBranch.
+func (p *PlainGit) UpdatePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The release branch might have been pushed again from another clone
+	if err = repo.Fetch(ctx); err != nil {
+		return err
+	}
+
+	commit, err := repo.RemoteBranchCommit(ctx, pr.Head)
+	if err != nil {
+		return err
+	}
+	if commit == nil {
+		return fmt.Errorf("release branch %s does not exist", pr.Head)
+	}
+
+	message := releaseCommitMessage(pr)
+	if commit.Message == message {
+		return nil
+	}
+
+	if _, err = repo.RewordRemoteBranch(ctx, pr.Head, message); err != nil {
+		return fmt.Errorf("failed to update description in release commit: %w", err)
+	}
+
+	return nil
+}
+
+// SetPullRequestLabels does nothing, the state of a release is tracked through the existence of the tag.
+func (p *PlainGit) SetPullRequestLabels(_ context.Context, _ *releasepr.ReleasePullRequest, _, _ []releasepr.Label) error {
+	return nil
+}
+
+// ClosePullRequest deletes the release branch.
+func (p *PlainGit) ClosePullRequest(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return err
+	}
+
+	return repo.DeleteRemoteBranch(ctx, pr.Head)
+}
+
+// PendingReleases returns the release commits on the base branch that were not tagged yet. Only commits that are not
+// reachable from any tag are considered. The description is read from the body of the commit message.
+func (p *PlainGit) PendingReleases(ctx context.Context, _ releasepr.Label) ([]*releasepr.ReleasePullRequest, error) {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := repo.UntaggedCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	titlePrefix := fmt.Sprintf(releasepr.TitleFormat, p.options.BaseBranch, "")
+
+	var prs []*releasepr.ReleasePullRequest
+	for _, commit := range commits {
+		pr := releasePullRequest(commit)
+		version, ok := strings.CutPrefix(pr.Title, titlePrefix)
+		if !ok || version == "" {
+			continue
+		}
+
+		exists, err := repo.HasTag(version)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			p.log.WarnContext(ctx, "release commit is not tagged, but the tag exists on another commit", "commit.hash", commit.Hash, "tag.name", version)
+			continue
+		}
+
+		pr.ReleaseCommit = &git.Commit{Hash: commit.Hash, Message: commit.Message}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// releaseCommitMessage returns the message of the release commit, with the title of the pull request as the subject and
+// the description as the body.
+func releaseCommitMessage(pr *releasepr.ReleasePullRequest) string {
+	return fmt.Sprintf("%s\n\n%s", pr.Title, pr.Description)
+}
+
+// releasePullRequest is the inverse of releaseCommitMessage.
+func releasePullRequest(commit git.Commit) *releasepr.ReleasePullRequest {
+	title, description, _ := strings.Cut(commit.Message, "\n")
+
+	return &releasepr.ReleasePullRequest{
+		PullRequest: git.PullRequest{
+			Title:       title,
+			Description: strings.TrimPrefix(description, "\n"),
+		},
+	}
+}
+
+// CreateRelease creates and pushes an annotated tag. The arguments prerelease and latest have no meaning in plain git
+// and are ignored.
+func (p *PlainGit) CreateRelease(ctx context.Context, commit git.Commit, title, changelog string, _, _ bool) error {
+	return p.createTag(ctx, commit, title, changelog)
+}
+
+func (p *PlainGit) createTag(ctx context.Context, commit git.Commit, name, changelog string) error {
+	repo, err := p.repository(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := name
+	if changelog != "" {
+		message = fmt.Sprintf("%s\n\n%s", name, changelog)
+	}
+
+	if err = repo.CreateTag(ctx, name, commit.Hash, message, p.options.Author); err != nil {
+		return err
+	}
+
+	if err = repo.PushTag(ctx, name); err != nil {
+		return fmt.Errorf("failed to push tag %s: %w", name, err)
+	}
+
+	p.log.InfoContext(ctx, "pushed tag", "tag.name", name, "commit.hash", commit.Hash)
+
+	return nil
+}
+
+func (o *Options) autodiscover() {
+	if o.Mode == "" {
+		o.Mode = ModeBranch
+	}
+
+	if o.Author.Name == "" {
+		o.Author.Name = os.Getenv(EnvAuthorName)
+	}
+	if o.Author.Name == "" {
+		o.Author.Name = defaultAuthorName
+	}
+
+	if o.Author.Email == "" {
+		o.Author.Email = os.Getenv(EnvAuthorEmail)
+	}
+	if o.Author.Email == "" {
+		o.Author.Email = defaultAuthorEmail
+	}
+}
+
+type Options struct {
+	forge.Options
+
+	// URL of the git remote, any URL supported by `git clone` works.
+	URL string
+	// Mode is either ModeBranch or ModeTag.
+	Mode string
+
+	Author git.Author
+
+	Username string
+	APIToken string //gosec:disable G117
+}
+
+func New(log *slog.Logger, options *Options) (*PlainGit, error) {
+	options.autodiscover()
+
+	if options.URL == "" {
+		return nil, fmt.Errorf("remote url is required")
+	}
+
+	if options.Mode != ModeBranch && options.Mode != ModeTag {
+		return nil, fmt.Errorf("unknown mode %q, must be %q or %q", options.Mode, ModeBranch, ModeTag)
+	}
+
+	p := &PlainGit{
+		options: options,
+
+		log: log.With("forge", "git"),
+	}
+
+	return p, nil
+}
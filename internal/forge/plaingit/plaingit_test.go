@@ -0,0 +1,321 @@
+package plaingit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/forge/fake"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+)
+
+const testReleaseBranch = "releaser-pleaser--branches--main"
+
+var testRemoteID atomic.Int64
+
+func newTestRemote(t *testing.T) *fake.Remote {
+	t.Helper()
+
+	remote, err := fake.NewRemote(fmt.Sprintf("plaingit-%d", testRemoteID.Add(1)), "main")
+	require.NoError(t, err)
+	t.Cleanup(remote.Close)
+
+	return remote
+}
+
+func newTestPlainGit(t *testing.T, remote *fake.Remote, mode string) *PlainGit {
+	t.Helper()
+
+	p, err := New(slog.New(slog.DiscardHandler), &Options{
+		Options: forge.Options{BaseBranch: "main"},
+		URL:     remote.URL(),
+		Mode:    mode,
+		Author:  git.Author{Name: "releaser-pleaser", Email: "releaser-pleaser@example.com"},
+	})
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *Options
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "default mode", options: &Options{URL: "https://example.com/foo.git"}, wantErr: assert.NoError},
+		{name: "tag mode", options: &Options{URL: "https://example.com/foo.git", Mode: ModeTag}, wantErr: assert.NoError},
+		{name: "missing url", options: &Options{}, wantErr: assert.Error},
+		{name: "unknown mode", options: &Options{URL: "https://example.com/foo.git", Mode: "merge"}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(slog.New(slog.DiscardHandler), tt.options)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestOptions_autodiscover(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv(EnvAuthorName, "")
+		t.Setenv(EnvAuthorEmail, "")
+
+		options := &Options{}
+		options.autodiscover()
+
+		assert.Equal(t, ModeBranch, options.Mode)
+		assert.Equal(t, git.Author{Name: defaultAuthorName, Email: defaultAuthorEmail}, options.Author)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(EnvAuthorName, "Jane Doe")
+		t.Setenv(EnvAuthorEmail, "jane@example.com")
+
+		options := &Options{}
+		options.autodiscover()
+
+		assert.Equal(t, git.Author{Name: "Jane Doe", Email: "jane@example.com"}, options.Author)
+	})
+
+	t.Run("options take precedence", func(t *testing.T) {
+		t.Setenv(EnvAuthorName, "Jane Doe")
+		t.Setenv(EnvAuthorEmail, "jane@example.com")
+
+		options := &Options{Mode: ModeTag, Author: git.Author{Name: "John Doe", Email: "john@example.com"}}
+		options.autodiscover()
+
+		assert.Equal(t, ModeTag, options.Mode)
+		assert.Equal(t, git.Author{Name: "John Doe", Email: "john@example.com"}, options.Author)
+	})
+}
+
+func TestPlainGit_PendingReleases(t *testing.T) {
+	ctx := context.Background()
+	remote := newTestRemote(t)
+
+	// Released and tagged
+	hash, err := remote.Commit("main", "chore(main): release v1.0.0", nil)
+	require.NoError(t, err)
+	require.NoError(t, remote.Tag("v1.0.0", hash))
+
+	// Tagged on another commit, e.g. released manually
+	_, err = remote.Commit("main", "chore(main): release v1.0.1", nil)
+	require.NoError(t, err)
+	hash, err = remote.Commit("main", "fix: foo", nil)
+	require.NoError(t, err)
+	require.NoError(t, remote.Tag("v1.0.1", hash))
+
+	_, err = remote.Commit("main", "feat: bar", nil)
+	require.NoError(t, err)
+	// Release commit of another branch
+	_, err = remote.Commit("main", "chore(release/0.x): release v0.9.0", nil)
+	require.NoError(t, err)
+	_, err = remote.Commit("main", "chore(main): release ", nil)
+	require.NoError(t, err)
+	pending, err := remote.Commit("main", "chore(main): release v1.1.0\n\nSome details", nil)
+	require.NoError(t, err)
+	_, err = remote.Commit("main", "fix: baz", nil)
+	require.NoError(t, err)
+
+	p := newTestPlainGit(t, remote, ModeBranch)
+	prs, err := p.PendingReleases(ctx, releasepr.LabelReleasePending)
+	require.NoError(t, err)
+
+	require.Len(t, prs, 1)
+	assert.Equal(t, "chore(main): release v1.1.0", prs[0].Title)
+	assert.Equal(t, "Some details", prs[0].Description)
+	require.NotNil(t, prs[0].ReleaseCommit)
+	assert.Equal(t, pending, prs[0].ReleaseCommit.Hash)
+
+	version, err := prs[0].Version()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0", version)
+}
+
+func TestPlainGit_CreatePullRequest(t *testing.T) {
+	newReleasePR := func(t *testing.T) *releasepr.ReleasePullRequest {
+		t.Helper()
+
+		pr, err := releasepr.NewReleasePullRequest(testReleaseBranch, "main", "v1.1.0", "### Features\n\n- foo\n", "")
+		require.NoError(t, err)
+
+		return pr
+	}
+
+	t.Run("branch mode", func(t *testing.T) {
+		ctx := context.Background()
+		remote := newTestRemote(t)
+		p := newTestPlainGit(t, remote, ModeBranch)
+		assert.False(t, p.MergesPullRequests())
+
+		head, err := remote.Head("main")
+		require.NoError(t, err)
+
+		existing, err := p.PullRequestForBranch(ctx, testReleaseBranch)
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		_, err = remote.Commit(testReleaseBranch, "chore(main): release v1.1.0", nil)
+		require.NoError(t, err)
+
+		pr := newReleasePR(t)
+		require.NoError(t, p.CreatePullRequest(ctx, pr))
+
+		got, err := remote.Head("main")
+		require.NoError(t, err)
+		assert.Equal(t, head, got)
+
+		// The description is stored in the release commit
+		releaseCommit, err := remote.Head(testReleaseBranch)
+		require.NoError(t, err)
+		commit, err := remote.Repository().CommitObject(plumbing.NewHash(releaseCommit))
+		require.NoError(t, err)
+		assert.Equal(t, pr.Title+"\n\n"+pr.Description, commit.Message)
+
+		existing, err = p.PullRequestForBranch(ctx, testReleaseBranch)
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.Equal(t, pr.Title, existing.Title)
+		assert.Equal(t, pr.Description, existing.Description)
+		assert.Equal(t, testReleaseBranch, existing.Head)
+
+		// Unchanged pull requests are not pushed again
+		require.NoError(t, p.UpdatePullRequest(ctx, existing))
+		got, err = remote.Head(testReleaseBranch)
+		require.NoError(t, err)
+		assert.Equal(t, releaseCommit, got)
+
+		prs, err := p.PendingReleases(ctx, releasepr.LabelReleasePending)
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+
+		// Merged by the user in another clone
+		merged, err := remote.SquashMerge("main", testReleaseBranch, commit.Message)
+		require.NoError(t, err)
+
+		p = newTestPlainGit(t, remote, ModeBranch)
+		prs, err = p.PendingReleases(ctx, releasepr.LabelReleasePending)
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, merged, prs[0].ReleaseCommit.Hash)
+
+		changelog, err := prs[0].ReleaseText()
+		require.NoError(t, err)
+		assert.Contains(t, changelog, "- foo")
+
+		require.NoError(t, p.ClosePullRequest(ctx, existing))
+		_, err = remote.Head(testReleaseBranch)
+		assert.Error(t, err)
+	})
+
+	t.Run("branch mode with updated pull request", func(t *testing.T) {
+		ctx := context.Background()
+		remote := newTestRemote(t)
+		p := newTestPlainGit(t, remote, ModeBranch)
+
+		_, err := remote.Commit(testReleaseBranch, "chore(main): release v1.1.0", nil)
+		require.NoError(t, err)
+		require.NoError(t, p.CreatePullRequest(ctx, newReleasePR(t)))
+
+		// Pushed again by the next run, with a new release commit
+		_, err = remote.Commit(testReleaseBranch, "chore(main): release v1.2.0", nil)
+		require.NoError(t, err)
+
+		pr, err := p.PullRequestForBranch(ctx, testReleaseBranch)
+		require.NoError(t, err)
+		require.NotNil(t, pr)
+		assert.Empty(t, pr.Description)
+
+		pr.SetTitle("main", "v1.2.0")
+		require.NoError(t, pr.SetDescription("### Features\n\n- bar\n", "", releasepr.ReleaseOverrides{}))
+		require.NoError(t, p.UpdatePullRequest(ctx, pr))
+
+		got, err := p.PullRequestForBranch(ctx, testReleaseBranch)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "chore(main): release v1.2.0", got.Title)
+		assert.Equal(t, pr.Description, got.Description)
+	})
+
+	t.Run("tag mode", func(t *testing.T) {
+		ctx := context.Background()
+		remote := newTestRemote(t)
+		p := newTestPlainGit(t, remote, ModeTag)
+		assert.True(t, p.MergesPullRequests())
+
+		// The clone is created before the release branch is pushed, like in a run of releaser-pleaser
+		prs, err := p.PendingReleases(ctx, releasepr.LabelReleasePending)
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+
+		_, err = remote.Commit(testReleaseBranch, "chore(main): release v1.1.0", nil)
+		require.NoError(t, err)
+
+		pr := newReleasePR(t)
+		require.NoError(t, p.CreatePullRequest(ctx, pr))
+
+		// The base branch is fast-forwarded to the release commit with the description and the release branch is deleted
+		releaseCommit, err := remote.Head("main")
+		require.NoError(t, err)
+		commit, err := remote.Repository().CommitObject(plumbing.NewHash(releaseCommit))
+		require.NoError(t, err)
+		assert.Equal(t, pr.Title+"\n\n"+pr.Description, commit.Message)
+		_, err = remote.Head(testReleaseBranch)
+		assert.Error(t, err)
+
+		// The tag is only created for the pending release
+		_, err = remote.Repository().Tag("v1.1.0")
+		assert.Error(t, err)
+
+		prs, err = p.PendingReleases(ctx, releasepr.LabelReleasePending)
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, pr.Title, prs[0].Title)
+		assert.Equal(t, releaseCommit, prs[0].ReleaseCommit.Hash)
+
+		changelog, err := prs[0].ReleaseText()
+		require.NoError(t, err)
+		assert.Contains(t, changelog, "- foo")
+
+		require.NoError(t, p.CreateRelease(ctx, *prs[0].ReleaseCommit, "v1.1.0", changelog, false, true))
+
+		ref, err := remote.Repository().Tag("v1.1.0")
+		require.NoError(t, err)
+		tag, err := remote.Repository().TagObject(ref.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, plumbing.NewHash(releaseCommit), tag.Target)
+		assert.Contains(t, tag.Message, "v1.1.0\n\n")
+		assert.Contains(t, tag.Message, "- foo")
+		assert.Equal(t, "releaser-pleaser@example.com", tag.Tagger.Email)
+
+		prs, err = p.PendingReleases(ctx, releasepr.LabelReleasePending)
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("tag mode with moved base branch", func(t *testing.T) {
+		ctx := context.Background()
+		remote := newTestRemote(t)
+		p := newTestPlainGit(t, remote, ModeTag)
+
+		_, err := remote.Commit(testReleaseBranch, "chore(main): release v1.1.0", nil)
+		require.NoError(t, err)
+		head, err := remote.Commit("main", "fix: pushed in the meantime", nil)
+		require.NoError(t, err)
+
+		require.Error(t, p.CreatePullRequest(ctx, newReleasePR(t)))
+
+		got, err := remote.Head("main")
+		require.NoError(t, err)
+		assert.Equal(t, head, got)
+	})
+}
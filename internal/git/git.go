@@ -2,10 +2,12 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -20,10 +22,14 @@ const (
 	newFilePermissions = 0o644
 )
 
+// ErrNoChanges is returned by Repository.Commit if the worktree has no changes.
+var ErrNoChanges = git.ErrEmptyCommit
+
 type Commit struct {
 	Hash    string
 	URL     string
 	Message string
+	Author  Author
 
 	PullRequest *PullRequest
 }
@@ -39,6 +45,9 @@ type PullRequest struct {
 	ID          int64
 	Title       string
 	Description string
+	URL         string
+	// Author is the username of the user that opened the pull request on the forge.
+	Author string
 }
 
 type Tag struct {
@@ -51,6 +60,54 @@ type Releases struct {
 	Stable *Tag
 }
 
+// ReleaseTags identifies the release tags of a component and orders them by version.
+type ReleaseTags interface {
+	// Version returns the version of the tag. ok is false if the tag is not a release of the component.
+	Version(tag string) (version string, ok bool)
+	// IsPrerelease checks if the version returned by Version is a pre-release.
+	IsPrerelease(version string) bool
+	// Compare returns a negative number if version a is lower than b, a positive number if a is greater and 0 if they
+	// are equal.
+	Compare(a, b string) int
+}
+
+// LatestReleases picks the release with the highest version and the stable release with the highest version from
+// tags. Tags that are not releases according to releaseTags are ignored.
+func LatestReleases(tags []Tag, releaseTags ReleaseTags) Releases {
+	type versionTag struct {
+		tag     Tag
+		version string
+	}
+
+	versionTags := make([]versionTag, 0, len(tags))
+	for _, tag := range tags {
+		version, ok := releaseTags.Version(tag.Name)
+		if !ok {
+			continue
+		}
+
+		versionTags = append(versionTags, versionTag{tag: tag, version: version})
+	}
+
+	// Highest version first
+	slices.SortStableFunc(versionTags, func(a, b versionTag) int {
+		return releaseTags.Compare(b.version, a.version)
+	})
+
+	var releases Releases
+	for _, vt := range versionTags {
+		if releases.Latest == nil {
+			releases.Latest = &vt.tag
+		}
+		if !releaseTags.IsPrerelease(vt.version) {
+			releases.Stable = &vt.tag
+			break
+		}
+	}
+
+	return releases
+}
+
 type Author struct {
 	Name  string
 	Email string
@@ -88,6 +145,21 @@ func CloneRepo(ctx context.Context, logger *slog.Logger, cloneURL, branch string
 	return &Repository{r: repo, logger: logger, auth: auth}, nil
 }
 
+// OpenRepo opens an existing repository at path or any of its parent directories.
+func OpenRepo(logger *slog.Logger, path string) (*Repository, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return &Repository{r: repo, logger: logger}, nil
+}
+
+// NewRepository wraps an existing go-git repository, for example one that only exists in memory.
+func NewRepository(logger *slog.Logger, repo *git.Repository, auth transport.AuthMethod) *Repository {
+	return &Repository{r: repo, logger: logger, auth: auth}
+}
+
 type Repository struct {
 	r      *git.Repository
 	logger *slog.Logger
@@ -145,7 +217,7 @@ func (r *Repository) UpdateFile(_ context.Context, path string, create bool, upd
 
 	updatedContent, err := updateHook(string(content))
 	if err != nil {
-		return fmt.Errorf("failed to run update hook on file %s", path)
+		return fmt.Errorf("failed to run update hook on file %s: %w", path, err)
 	}
 
 	err = file.Truncate(0)
@@ -169,6 +241,31 @@ func (r *Repository) UpdateFile(_ context.Context, path string, create bool, upd
 	return nil
 }
 
+// Dir returns the path of the worktree on disk.
+func (r *Repository) Dir() (string, error) {
+	worktree, err := r.r.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	return worktree.Filesystem.Root(), nil
+}
+
+// AddAll stages all changes in the worktree, including new and deleted files. Ignored files are not staged.
+func (r *Repository) AddAll(_ context.Context) error {
+	worktree, err := r.r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("failed to add changes to git worktree: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Repository) Commit(_ context.Context, message string, author Author) (Commit, error) {
 	worktree, err := r.r.Worktree()
 	if err != nil {
@@ -191,6 +288,50 @@ func (r *Repository) Commit(_ context.Context, message string, author Author) (C
 	}, nil
 }
 
+// ChangedFiles returns the paths of all files that were added, modified or removed in the commit, compared to its first
+// parent. For renamed files both the old and the new path are returned.
+func (r *Repository) ChangedFiles(ctx context.Context, hash string) ([]string, error) {
+	commit, err := r.r.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTreeContext(ctx, parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.From.Name != "" {
+			files = append(files, change.From.Name)
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			files = append(files, change.To.Name)
+		}
+	}
+
+	return files, nil
+}
+
 // HasChangesWithRemote checks if the following two diffs are equal:
 //
 // - **Local**:                                 remote/main..branch
@@ -249,6 +390,33 @@ func (r *Repository) hasChangesWithRemote(ctx context.Context, mainBranchRef, lo
 	return remoteDiff.String() != localDiff.String(), nil
 }
 
+// DiffWithRemote returns the unified diff between remote/main and the local branch.
+func (r *Repository) DiffWithRemote(ctx context.Context, mainBranch, branch string) (string, error) {
+	return r.diff(ctx,
+		plumbing.NewRemoteReferenceName(remoteName, mainBranch),
+		plumbing.NewBranchReferenceName(branch),
+	)
+}
+
+func (r *Repository) diff(ctx context.Context, fromRef, toRef plumbing.ReferenceName) (string, error) {
+	from, err := r.commitFromRef(fromRef)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := r.commitFromRef(toRef)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := from.PatchContext(ctx, to)
+	if err != nil {
+		return "", err
+	}
+
+	return patch.String(), nil
+}
+
 func (r *Repository) commitFromRef(refName plumbing.ReferenceName) (*object.Commit, error) {
 	ref, err := r.r.Reference(refName, false)
 	if err != nil {
@@ -294,3 +462,166 @@ func (r *Repository) ForcePush(ctx context.Context, branch string) error {
 		Auth:       r.auth,
 	})
 }
+
+// Push pushes the local branch to the branch of the same name on the remote. The push is not forced, so it fails if
+// the remote branch has commits that are not in the local branch.
+func (r *Repository) Push(ctx context.Context, branch string) error {
+	ref := plumbing.NewBranchReferenceName(branch)
+	pushRefSpec := config.RefSpec(fmt.Sprintf("%s:%s", ref, ref))
+
+	r.logger.DebugContext(ctx, "pushing branch", "branch.name", branch, "refspec", pushRefSpec.String())
+	return r.r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{pushRefSpec},
+		Auth:       r.auth,
+	})
+}
+
+// Fetch updates all remote-tracking branches and tags from the remote.
+func (r *Repository) Fetch(ctx context.Context) error {
+	r.logger.DebugContext(ctx, "fetching from remote")
+	err := r.r.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", remoteName)),
+			"+refs/tags/*:refs/tags/*",
+		},
+		Auth: r.auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch from remote: %w", err)
+	}
+
+	return nil
+}
+
+// PushRemoteBranch updates the branch `to` on the remote to the commit of the remote-tracking branch `from`. The push
+// is not forced, so it fails if `to` can not be fast-forwarded. Call Fetch before to get the current state of `from`.
+func (r *Repository) PushRemoteBranch(ctx context.Context, from, to string) (Commit, error) {
+	commit, err := r.commitFromRef(plumbing.NewRemoteReferenceName(remoteName, from))
+	if err != nil {
+		return Commit{}, fmt.Errorf("failed to get commit of remote branch %s: %w", from, err)
+	}
+
+	pushRefSpec := config.RefSpec(fmt.Sprintf(
+		"%s:%s",
+		plumbing.NewRemoteReferenceName(remoteName, from),
+		plumbing.NewBranchReferenceName(to),
+	))
+
+	r.logger.DebugContext(ctx, "pushing remote branch", "branch.from", from, "branch.to", to, "refspec", pushRefSpec.String())
+	err = r.r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{pushRefSpec},
+		Auth:       r.auth,
+	})
+	if err != nil {
+		return Commit{}, err
+	}
+
+	return Commit{Hash: commit.Hash.String(), Message: commit.Message}, nil
+}
+
+// RemoteBranchCommit returns the commit of the remote-tracking branch, or nil if the branch does not exist on the
+// remote. Call Fetch before to get the current state of the branch.
+func (r *Repository) RemoteBranchCommit(_ context.Context, branch string) (*Commit, error) {
+	commit, err := r.commitFromRef(plumbing.NewRemoteReferenceName(remoteName, branch))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get commit of remote branch %s: %w", branch, err)
+	}
+
+	return &Commit{Hash: commit.Hash.String(), Message: commit.Message}, nil
+}
+
+// RewordRemoteBranch replaces the message of the last commit of the remote-tracking branch and force pushes the new
+// commit to the branch on the remote. The content, parents and author of the commit are kept. Call Fetch before to get
+// the current state of the branch.
+func (r *Repository) RewordRemoteBranch(ctx context.Context, branch, message string) (Commit, error) {
+	refName := plumbing.NewRemoteReferenceName(remoteName, branch)
+	commit, err := r.commitFromRef(refName)
+	if err != nil {
+		return Commit{}, fmt.Errorf("failed to get commit of remote branch %s: %w", branch, err)
+	}
+
+	reworded := &object.Commit{
+		Author:       commit.Author,
+		Committer:    commit.Committer,
+		Message:      message,
+		TreeHash:     commit.TreeHash,
+		ParentHashes: commit.ParentHashes,
+	}
+
+	obj := r.r.Storer.NewEncodedObject()
+	if err = reworded.Encode(obj); err != nil {
+		return Commit{}, fmt.Errorf("failed to encode commit: %w", err)
+	}
+	hash, err := r.r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return Commit{}, fmt.Errorf("failed to store commit: %w", err)
+	}
+	if err = r.r.Storer.SetReference(plumbing.NewHashReference(refName, hash)); err != nil {
+		return Commit{}, err
+	}
+
+	pushRefSpec := config.RefSpec(fmt.Sprintf("+%s:%s", refName, plumbing.NewBranchReferenceName(branch)))
+
+	r.logger.DebugContext(ctx, "pushing reworded commit", "branch.name", branch, "commit.hash", hash.String(), "refspec", pushRefSpec.String())
+	err = r.r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{pushRefSpec},
+		Force:      true,
+		Auth:       r.auth,
+	})
+	if err != nil {
+		return Commit{}, err
+	}
+
+	return Commit{Hash: hash.String(), Message: message}, nil
+}
+
+// DeleteRemoteBranch deletes the branch on the remote.
+func (r *Repository) DeleteRemoteBranch(ctx context.Context, branch string) error {
+	pushRefSpec := config.RefSpec(":" + plumbing.NewBranchReferenceName(branch).String())
+
+	r.logger.DebugContext(ctx, "deleting remote branch", "branch.name", branch)
+	err := r.r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{pushRefSpec},
+		Auth:       r.auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+
+	return nil
+}
+
+// CreateTag creates an annotated tag pointing at the commit.
+func (r *Repository) CreateTag(ctx context.Context, name, hash, message string, author Author) error {
+	r.logger.DebugContext(ctx, "creating tag", "tag.name", name, "commit.hash", hash)
+	_, err := r.r.CreateTag(name, plumbing.NewHash(hash), &git.CreateTagOptions{
+		Tagger:  author.signature(time.Now()),
+		Message: message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// PushTag pushes the tag to the remote.
+func (r *Repository) PushTag(ctx context.Context, name string) error {
+	ref := plumbing.NewTagReferenceName(name)
+	pushRefSpec := config.RefSpec(fmt.Sprintf("%s:%s", ref, ref))
+
+	r.logger.DebugContext(ctx, "pushing tag", "tag.name", name, "refspec", pushRefSpec.String())
+	return r.r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{pushRefSpec},
+		Auth:       r.auth,
+	})
+}
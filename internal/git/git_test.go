@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
@@ -194,3 +195,220 @@ func TestRepository_CommitUsesAuthorAsCommitter(t *testing.T) {
 	assert.Equal(t, author.Name, obj.Committer.Name)
 	assert.Equal(t, author.Email, obj.Committer.Email)
 }
+
+func TestRepository_AddAll(t *testing.T) {
+	repo := WithTestRepo()(t)
+
+	worktree, err := repo.r.Worktree()
+	require.NoError(t, err)
+
+	file, err := worktree.Filesystem.Create("generated/version.txt")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("1.2.3"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, worktree.Filesystem.Remove("README.md"))
+
+	require.NoError(t, repo.AddAll(context.Background()))
+
+	commit, err := repo.Commit(context.Background(), "chore: release v1.2.3", Author{Name: "release bot", Email: "release@example.com"})
+	require.NoError(t, err)
+
+	got, err := repo.ChangedFiles(context.Background(), commit.Hash)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"README.md", "generated/version.txt"}, got)
+}
+
+func TestRepository_ChangedFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		repo TestRepo
+		want []string
+	}{
+		{
+			name: "initial commit",
+			repo: WithTestRepo(),
+			want: []string{"README.md"},
+		},
+		{
+			name: "multiple files",
+			repo: WithTestRepo(
+				WithCommit(
+					"feat: add api",
+					WithFile("api/main.go", "package main"),
+					WithFile("web/index.html", "<html>"),
+				),
+			),
+			want: []string{"api/main.go", "web/index.html"},
+		},
+		{
+			name: "modified file",
+			repo: WithTestRepo(
+				WithCommit(
+					"docs: update readme",
+					WithFile("README.md", "# Updated"),
+				),
+			),
+			want: []string{"README.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.repo(t)
+
+			head, err := repo.r.Head()
+			require.NoError(t, err)
+
+			got, err := repo.ChangedFiles(context.Background(), head.Hash().String())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_diff(t *testing.T) {
+	mainBranchRef := plumbing.NewBranchReferenceName(testMainBranch)
+	localPRBranchRef := plumbing.NewBranchReferenceName(testPRBranch)
+
+	repo := WithTestRepo(
+		WithCommit(
+			"chore: release v1.0.0",
+			WithFile("VERSION", "v1.0.0\n"),
+		),
+		WithCommit(
+			"chore: release v1.1.0",
+			OnBranch(mainBranchRef),
+			AsNewBranch(localPRBranchRef),
+			WithFile("VERSION", "v1.1.0\n"),
+		),
+	)(t)
+
+	got, err := repo.diff(context.Background(), mainBranchRef, localPRBranchRef)
+	require.NoError(t, err)
+	assert.Equal(t, `diff --git a/VERSION b/VERSION
+index 0ec25f7505c14bad77f34453b2730417ab431293..795460fcec8812040dd07965dc70d61d221059c9 100644
+--- a/VERSION
++++ b/VERSION
+@@ -1 +1 @@
+-v1.0.0
++v1.1.0
+`, got)
+}
+
+func TestRepository_PushRemoteBranch(t *testing.T) {
+	ctx := context.Background()
+
+	origin := WithTestRepo(
+		WithCommit("chore(main): release v1.0.0", AsNewBranch("refs/heads/release"), OnBranch(plumbing.Main)),
+	)(t)
+	remote := WithBareRemote(t, origin)
+
+	releaseRef, err := origin.r.Reference("refs/heads/release", false)
+	require.NoError(t, err)
+
+	repo, err := CloneRepo(ctx, origin.logger, remote, "main", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Fetch(ctx))
+
+	commit, err := repo.PushRemoteBranch(ctx, "release", "main")
+	require.NoError(t, err)
+	assert.Equal(t, releaseRef.Hash().String(), commit.Hash)
+
+	require.NoError(t, repo.CreateTag(ctx, "v1.0.0", commit.Hash, "v1.0.0", Author{Name: "releaser-pleaser"}))
+	require.NoError(t, repo.PushTag(ctx, "v1.0.0"))
+
+	require.NoError(t, repo.DeleteRemoteBranch(ctx, "release"))
+
+	bare, err := git.PlainOpen(remote)
+	require.NoError(t, err)
+	remoteRepo := &Repository{r: bare, logger: origin.logger}
+
+	mainRef, err := remoteRepo.r.Reference(plumbing.Main, false)
+	require.NoError(t, err)
+	assert.Equal(t, commit.Hash, mainRef.Hash().String())
+
+	exists, err := remoteRepo.HasTag("v1.0.0")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = remoteRepo.r.Reference("refs/heads/release", false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
+
+func TestRepository_Push(t *testing.T) {
+	ctx := context.Background()
+
+	origin := WithTestRepo(WithCommit("chore: init"))(t)
+	remote := WithBareRemote(t, origin)
+
+	repo, err := CloneRepo(ctx, origin.logger, remote, "main", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.UpdateFile(ctx, "VERSION", true, func(string) (string, error) { return "1.0.1-SNAPSHOT", nil }))
+	commit, err := repo.Commit(ctx, "chore(main): prepare next development iteration", Author{Name: "releaser-pleaser"})
+	require.NoError(t, err)
+
+	_, err = repo.Commit(ctx, "chore: nothing", Author{Name: "releaser-pleaser"})
+	assert.ErrorIs(t, err, ErrNoChanges)
+
+	require.NoError(t, repo.Push(ctx, "main"))
+
+	bare, err := git.PlainOpen(remote)
+	require.NoError(t, err)
+	mainRef, err := bare.Reference(plumbing.Main, false)
+	require.NoError(t, err)
+	assert.Equal(t, commit.Hash, mainRef.Hash().String())
+}
+
+func TestLatestReleases(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []Tag
+		want Releases
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+			want: Releases{},
+		},
+		{
+			name: "ordered by version",
+			tags: []Tag{
+				{Hash: "1", Name: "v1.9.0"},
+				{Hash: "2", Name: "v1.10.0-rc.0"},
+				{Hash: "3", Name: "v1.2.0"},
+			},
+			want: Releases{
+				Latest: &Tag{Hash: "2", Name: "v1.10.0-rc.0"},
+				Stable: &Tag{Hash: "1", Name: "v1.9.0"},
+			},
+		},
+		{
+			name: "ignores other tags",
+			tags: []Tag{
+				{Hash: "1", Name: "latest"},
+				{Hash: "2", Name: "api/v2.0.0"},
+				{Hash: "3", Name: "v1.0.0"},
+			},
+			want: Releases{
+				Latest: &Tag{Hash: "3", Name: "v1.0.0"},
+				Stable: &Tag{Hash: "3", Name: "v1.0.0"},
+			},
+		},
+		{
+			name: "only prereleases",
+			tags: []Tag{
+				{Hash: "1", Name: "v1.0.0-alpha.0"},
+			},
+			want: Releases{
+				Latest: &Tag{Hash: "1", Name: "v1.0.0-alpha.0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LatestReleases(tt.tags, semverTags{}))
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ReadFile returns the content of the file at path in the worktree. If the file does not exist, it returns nil.
+func (r *Repository) ReadFile(_ context.Context, path string) ([]byte, error) {
+	worktree, err := r.r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := worktree.Filesystem.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	return io.ReadAll(file)
+}
+
+// ReadFileBefore returns the content of the file at path in the first parent of the commit. If the commit has no
+// parent or the file does not exist, it returns nil.
+func (r *Repository) ReadFileBefore(_ context.Context, hash, path string) ([]byte, error) {
+	commit, err := r.r.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+	if commit.NumParents() == 0 {
+		return nil, nil
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parent.File(path)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
+
+// LatestTags returns the latest release and the latest stable release of the tags in the repository. releaseTags
+// decides which tags are releases.
+func (r *Repository) LatestTags(_ context.Context, releaseTags ReleaseTags) (Releases, error) {
+	tags, err := r.releaseTags(releaseTags, nil)
+	if err != nil {
+		return Releases{}, err
+	}
+
+	return LatestReleases(tags, releaseTags), nil
+}
+
+// LatestReachableTags is like LatestTags, but only considers tags that point at commits reachable from HEAD. Tags
+// created on other branches are ignored.
+func (r *Repository) LatestReachableTags(_ context.Context, releaseTags ReleaseTags) (Releases, error) {
+	head, err := r.r.Head()
+	if err != nil {
+		return Releases{}, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	reachable := map[plumbing.Hash]bool{}
+	commits, err := r.r.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return Releases{}, err
+	}
+	err = commits.ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return Releases{}, err
+	}
+
+	tags, err := r.releaseTags(releaseTags, func(hash plumbing.Hash) bool { return reachable[hash] })
+	if err != nil {
+		return Releases{}, err
+	}
+
+	return LatestReleases(tags, releaseTags), nil
+}
+
+// releaseTags returns all tags that are releases according to releaseTags. If filter is not nil, only tags pointing
+// at commits that match the filter are returned.
+func (r *Repository) releaseTags(releaseTags ReleaseTags, filter func(plumbing.Hash) bool) ([]Tag, error) {
+	refs, err := r.r.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if _, ok := releaseTags.Version(ref.Name().Short()); !ok {
+			return nil
+		}
+
+		hash, err := r.tagCommitHash(ref)
+		if err != nil {
+			return err
+		}
+		if filter != nil && !filter(hash) {
+			return nil
+		}
+
+		tags = append(tags, Tag{Hash: hash.String(), Name: ref.Name().Short()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// tagCommitHash resolves annotated tags to the commit they point at.
+func (r *Repository) tagCommitHash(ref *plumbing.Reference) (plumbing.Hash, error) {
+	tagObject, err := r.r.TagObject(ref.Hash())
+	switch {
+	case err == nil:
+		commit, err := tagObject.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to resolve tag %s: %w", ref.Name().Short(), err)
+		}
+		return commit.Hash, nil
+	case errors.Is(err, plumbing.ErrObjectNotFound):
+		// Lightweight tag
+		return ref.Hash(), nil
+	default:
+		return plumbing.ZeroHash, err
+	}
+}
+
+// Head returns the hash of the commit that HEAD points at.
+func (r *Repository) Head(_ context.Context) (string, error) {
+	head, err := r.r.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
+// CommitsSince returns all commits reachable from HEAD that are not reachable from the tag. The tag can be `nil`, in
+// which case all commits are returned. The newest commit is returned first.
+func (r *Repository) CommitsSince(ctx context.Context, tag *Tag) ([]Commit, error) {
+	head, err := r.r.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	var exclude []plumbing.Hash
+	if tag != nil {
+		exclude = append(exclude, plumbing.NewHash(tag.Hash))
+	}
+
+	return r.commitsBetween(ctx, exclude, head.Hash())
+}
+
+// UntaggedCommits returns all commits reachable from HEAD that are not reachable from any tag. The newest commit is
+// returned first.
+func (r *Repository) UntaggedCommits(ctx context.Context) ([]Commit, error) {
+	head, err := r.r.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	refs, err := r.r.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var exclude []plumbing.Hash
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		hash, err := r.tagCommitHash(ref)
+		if err != nil {
+			return err
+		}
+
+		exclude = append(exclude, hash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.commitsBetween(ctx, exclude, head.Hash())
+}
+
+// HasTag checks if a tag with the name exists.
+func (r *Repository) HasTag(name string) (bool, error) {
+	_, err := r.r.Tag(name)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, git.ErrTagNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (r *Repository) commitsBetween(ctx context.Context, exclude []plumbing.Hash, head plumbing.Hash) ([]Commit, error) {
+	excluded := map[plumbing.Hash]bool{}
+	for _, hash := range exclude {
+		if excluded[hash] {
+			continue
+		}
+
+		excludedCommits, err := r.r.Log(&git.LogOptions{From: hash})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", hash, err)
+		}
+
+		err = excludedCommits.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	headCommits, err := r.r.Log(&git.LogOptions{From: head})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var commits []Commit
+	err = headCommits.ForEach(func(c *object.Commit) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if excluded[c.Hash] {
+			return nil
+		}
+
+		commits = append(commits, Commit{
+			Hash:    c.Hash.String(),
+			Message: c.Message,
+			Author:  Author{Name: c.Author.Name, Email: c.Author.Email},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,177 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ReadFile(t *testing.T) {
+	repo := WithTestRepo(
+		WithCommit("feat: add version", WithFile("VERSION", "v1.0.0")),
+	)(t)
+
+	content, err := repo.ReadFile(context.Background(), "VERSION")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1.0.0"), content)
+
+	content, err = repo.ReadFile(context.Background(), "missing.txt")
+	require.NoError(t, err)
+	assert.Nil(t, content)
+}
+
+func TestRepository_LatestTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       TestRepo
+		prefix     string
+		wantLatest string
+		wantStable string
+	}{
+		{
+			name: "no tags",
+			repo: WithTestRepo(),
+		},
+		{
+			name: "stable and prerelease",
+			repo: WithTestRepo(
+				WithCommit("feat: foo", WithTag("v1.0.0")),
+				WithCommit("feat: bar", WithTag("v1.1.0-rc.0")),
+			),
+			wantLatest: "v1.1.0-rc.0",
+			wantStable: "v1.0.0",
+		},
+		{
+			name: "sorted by version",
+			repo: WithTestRepo(
+				WithCommit("feat: foo", WithTag("v2.0.0")),
+				WithCommit("fix: bar", WithTag("v1.9.3")),
+			),
+			wantLatest: "v2.0.0",
+			wantStable: "v2.0.0",
+		},
+		{
+			name: "ignores invalid tags",
+			repo: WithTestRepo(
+				WithCommit("feat: foo", WithTag("v1.0.0")),
+				WithCommit("feat: bar", WithTag("latest")),
+			),
+			wantLatest: "v1.0.0",
+			wantStable: "v1.0.0",
+		},
+		{
+			name: "prefix",
+			repo: WithTestRepo(
+				WithCommit("feat: foo", WithTag("api/v1.0.0")),
+				WithCommit("feat: bar", WithTag("v2.0.0")),
+			),
+			prefix:     "api/",
+			wantLatest: "api/v1.0.0",
+			wantStable: "api/v1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.repo(t)
+
+			got, err := repo.LatestTags(context.Background(), semverTags{prefix: tt.prefix})
+			require.NoError(t, err)
+
+			if tt.wantLatest == "" {
+				assert.Nil(t, got.Latest)
+			} else {
+				require.NotNil(t, got.Latest)
+				assert.Equal(t, tt.wantLatest, got.Latest.Name)
+			}
+
+			if tt.wantStable == "" {
+				assert.Nil(t, got.Stable)
+			} else {
+				require.NotNil(t, got.Stable)
+				assert.Equal(t, tt.wantStable, got.Stable.Name)
+			}
+		})
+	}
+}
+
+func TestRepository_LatestReachableTags(t *testing.T) {
+	mainRef := plumbing.NewBranchReferenceName("main")
+	releaseRef := plumbing.NewBranchReferenceName("release/1.x")
+
+	repo := WithTestRepo(
+		WithCommit("feat: foo", WithTag("v1.0.0")),
+		WithCommit("fix: foo", OnBranch(mainRef), AsNewBranch(releaseRef), WithTag("v1.0.1")),
+		WithCommit("feat!: bar", OnBranch(mainRef), WithTag("v2.0.0")),
+		WithCommit("feat: baz", OnBranch(releaseRef), WithTag("v1.1.0-rc.0")),
+	)(t)
+
+	got, err := repo.LatestReachableTags(context.Background(), semverTags{})
+	require.NoError(t, err)
+	require.NotNil(t, got.Latest)
+	assert.Equal(t, "v1.1.0-rc.0", got.Latest.Name)
+	require.NotNil(t, got.Stable)
+	assert.Equal(t, "v1.0.1", got.Stable.Name)
+
+	got, err = repo.LatestTags(context.Background(), semverTags{})
+	require.NoError(t, err)
+	require.NotNil(t, got.Stable)
+	assert.Equal(t, "v2.0.0", got.Stable.Name)
+}
+
+func TestRepository_CommitsSince(t *testing.T) {
+	repo := WithTestRepo(
+		WithCommit("feat: foo", WithTag("v1.0.0")),
+		WithCommit("feat: bar"),
+		WithCommit("fix: baz"),
+	)(t)
+
+	tagRef, err := repo.r.Tag("v1.0.0")
+	require.NoError(t, err)
+
+	commits, err := repo.CommitsSince(context.Background(), &Tag{Hash: tagRef.Hash().String(), Name: "v1.0.0"})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "fix: baz", commits[0].Message)
+	assert.Equal(t, "feat: bar", commits[1].Message)
+
+	commits, err = repo.CommitsSince(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, commits, 4)
+
+	head, err := repo.r.Head()
+	require.NoError(t, err)
+	commits, err = repo.CommitsSince(context.Background(), &Tag{Hash: head.Hash().String(), Name: "head"})
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+}
+
+func TestRepository_UntaggedCommits(t *testing.T) {
+	repo := WithTestRepo(
+		WithCommit("feat: foo", WithTag("v1.0.0")),
+		WithCommit("chore(main): release v1.1.0"),
+		WithCommit("fix: bar"),
+	)(t)
+
+	commits, err := repo.UntaggedCommits(context.Background())
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "fix: bar", commits[0].Message)
+	assert.Equal(t, "chore(main): release v1.1.0", commits[1].Message)
+}
+
+func TestRepository_HasTag(t *testing.T) {
+	repo := WithTestRepo(
+		WithCommit("feat: foo", WithTag("v1.0.0")),
+	)(t)
+
+	exists, err := repo.HasTag("v1.0.0")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.HasTag("v2.0.0")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -5,11 +5,14 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
@@ -187,3 +190,58 @@ func WithTestRepo(commits ...TestCommit) TestRepo {
 		return repo
 	}
 }
+
+// WithBareRemote creates a bare repository in a temporary directory, adds it as the remote of repo and pushes all
+// branches and tags. It returns the path of the bare repository, which can be used as the clone URL.
+func WithBareRemote(t *testing.T, repo *Repository) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, true)
+	require.NoError(t, err, "failed to create bare repository")
+
+	_, err = repo.r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{dir}})
+	require.NoError(t, err, "failed to add remote")
+
+	err = repo.r.Push(&git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	require.NoError(t, err, "failed to push to remote")
+
+	return dir
+}
+
+// semverTags is a minimal ReleaseTags for semantic versions with a `v` prefix, versioning.ReleaseTags can not be used
+// because of the import cycle.
+type semverTags struct {
+	prefix string
+}
+
+func (s semverTags) parse(version string) (semver.Version, error) {
+	return semver.Parse(strings.TrimPrefix(version, "v"))
+}
+
+func (s semverTags) Version(tag string) (string, bool) {
+	version, ok := strings.CutPrefix(tag, s.prefix)
+	if !ok {
+		return "", false
+	}
+
+	if _, err := s.parse(version); err != nil {
+		return "", false
+	}
+
+	return version, true
+}
+
+func (s semverTags) IsPrerelease(version string) bool {
+	v, _ := s.parse(version)
+	return len(v.Pre) > 0
+}
+
+func (s semverTags) Compare(a, b string) int {
+	versionA, _ := s.parse(a)
+	versionB, _ := s.parse(b)
+	return versionA.Compare(versionB)
+}
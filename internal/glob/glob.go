@@ -0,0 +1,103 @@
+// Package glob matches paths against glob patterns. In addition to the syntax of path.Match, a `**` segment matches
+// any number of directories.
+package glob
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Validate returns an error if the pattern is malformed.
+func Validate(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether the slash-separated name matches the pattern. The pattern must be validated with Validate.
+func Match(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// Glob returns the names of all files and directories on disk that match the pattern, like filepath.Glob. Patterns
+// with a `**` segment walk the directory before the first segment with a wildcard, the names are returned in lexical
+// order.
+func Glob(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(pattern)
+	if err := Validate(pattern); err != nil {
+		return nil, err
+	}
+
+	segments := strings.Split(pattern, "/")
+	if !slices.Contains(segments, "**") {
+		return filepath.Glob(filepath.FromSlash(pattern))
+	}
+
+	wildcard := slices.IndexFunc(segments, func(segment string) bool { return strings.ContainsAny(segment, "*?[") })
+	root := strings.Join(segments[:wildcard], "/")
+	switch {
+	case root == "" && strings.HasPrefix(pattern, "/"):
+		root = "/"
+	case root == "":
+		root = "."
+	}
+	rest := strings.Join(segments[wildcard:], "/")
+
+	var matches []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(name string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(filepath.FromSlash(root), name)
+		if err != nil {
+			return err
+		}
+		if rel != "." && Match(rest, filepath.ToSlash(rel)) {
+			matches = append(matches, name)
+		}
+
+		return nil
+	})
+	// Like filepath.Glob, a missing directory is not an error
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
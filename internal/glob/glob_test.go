@@ -0,0 +1,115 @@
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("docs/**/*.md"))
+	assert.Error(t, Validate("docs/[/*.md"))
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*.md", name: "README.md", want: true},
+		{pattern: "*.md", name: "docs/README.md", want: false},
+		{pattern: "docs/*.md", name: "docs/README.md", want: true},
+		{pattern: "docs/**/*.md", name: "docs/README.md", want: true},
+		{pattern: "docs/**/*.md", name: "docs/guides/v1/install.md", want: true},
+		{pattern: "docs/**/*.md", name: "README.md", want: false},
+		{pattern: "**", name: "docs/guides/install.md", want: true},
+		{pattern: "**/version.go", name: "version.go", want: true},
+		{pattern: "**/version.go", name: "internal/version/version.go", want: true},
+		{pattern: "docs/**", name: "docs", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.pattern, tt.name))
+		})
+	}
+}
+
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dist/app.tar.gz", "dist/linux/amd64/app.tar.gz", "dist/linux/app.zip", "README.md"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "without double star",
+			pattern: "dist/*",
+			want:    []string{"dist/app.tar.gz", "dist/linux"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "double star",
+			pattern: "dist/**/*.tar.gz",
+			want:    []string{"dist/app.tar.gz", "dist/linux/amd64/app.tar.gz"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "leading double star",
+			pattern: "**/app.zip",
+			want:    []string{"dist/linux/app.zip"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "missing directory",
+			pattern: "build/**/*.tar.gz",
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid pattern",
+			pattern: "dist/**/[",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("relative", func(t *testing.T) {
+				t.Chdir(dir)
+
+				got, err := Glob(tt.pattern)
+				if !tt.wantErr(t, err) || err != nil {
+					return
+				}
+
+				var want []string
+				for _, name := range tt.want {
+					want = append(want, filepath.FromSlash(name))
+				}
+				assert.Equal(t, want, got)
+			})
+
+			t.Run("absolute", func(t *testing.T) {
+				got, err := Glob(filepath.Join(dir, tt.pattern))
+				if !tt.wantErr(t, err) || err != nil {
+					return
+				}
+
+				var want []string
+				for _, name := range tt.want {
+					want = append(want, filepath.Join(dir, name))
+				}
+				assert.Equal(t, want, got)
+			})
+		})
+	}
+}
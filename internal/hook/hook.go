@@ -0,0 +1,47 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// The environment variables that are passed to hooks. Not all variables are available at every hook point.
+const (
+	EnvComponent  = "RP_COMPONENT"
+	EnvVersion    = "RP_VERSION"
+	EnvTag        = "RP_TAG"
+	EnvCommit     = "RP_COMMIT"
+	EnvReleaseURL = "RP_RELEASE_URL"
+	EnvPrerelease = "RP_PRERELEASE"
+)
+
+// Run executes the shell command with `sh -c` in dir. The variables in env are added to the environment of the
+// process. The combined output is logged and included in the error if the command fails.
+func Run(ctx context.Context, logger *slog.Logger, command, dir string, env map[string]string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	logger.InfoContext(ctx, "running hook", "hook.command", command, "hook.dir", dir)
+	err := cmd.Run()
+	trimmedOutput := strings.TrimSpace(output.String())
+	if err != nil {
+		return fmt.Errorf("hook %q failed: %w: %s", command, err, trimmedOutput)
+	}
+
+	logger.InfoContext(ctx, "hook finished", "hook.command", command, "hook.output", trimmedOutput)
+
+	return nil
+}
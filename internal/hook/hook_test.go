@@ -0,0 +1,57 @@
+package hook
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		env     map[string]string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "writes file in dir",
+			command: "echo foo > out.txt",
+			want:    "foo\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "environment",
+			command: `printf '%s %s' "$RP_VERSION" "$RP_TAG" > out.txt`,
+			env:     map[string]string{EnvVersion: "1.2.0", EnvTag: "v1.2.0"},
+			want:    "1.2.0 v1.2.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "failing command",
+			command: "echo something went wrong; exit 3",
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorContains(t, err, "exit status 3") && assert.ErrorContains(t, err, "something went wrong")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			err := Run(context.Background(), slog.New(slog.DiscardHandler), tt.command, dir, tt.env)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
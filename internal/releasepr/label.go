@@ -52,3 +52,15 @@ var KnownLabels = []Label{
 	LabelReleasePending,
 	LabelReleaseTagged,
 }
+
+// LabelFromName returns the known label with the name. Other labels, e.g. the labels from the configuration, only
+// have the name set, as the forges do not return the color and description of pull request labels.
+func LabelFromName(name string) Label {
+	for _, label := range KnownLabels {
+		if label.Name == name {
+			return label
+		}
+	}
+
+	return Label{Name: name}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/apricote/releaser-pleaser/internal/git"
@@ -36,14 +37,14 @@ type ReleasePullRequest struct {
 	ReleaseCommit *git.Commit
 }
 
-func NewReleasePullRequest(head, branch, version, changelogEntry string) (*ReleasePullRequest, error) {
+func NewReleasePullRequest(head, branch, version, changelogEntry, releaseNotes string) (*ReleasePullRequest, error) {
 	rp := &ReleasePullRequest{
 		Head:   head,
 		Labels: []Label{LabelReleasePending},
 	}
 
 	rp.SetTitle(branch, version)
-	if err := rp.SetDescription(changelogEntry, ReleaseOverrides{}); err != nil {
+	if err := rp.SetDescription(changelogEntry, releaseNotes, ReleaseOverrides{}); err != nil {
 		return nil, err
 	}
 
@@ -54,15 +55,19 @@ type ReleaseOverrides struct {
 	Prefix          string
 	Suffix          string
 	NextVersionType versioning.NextVersionType
+	// Version replaces the calculated version of the next release. It is empty if the version is not forced.
+	Version string
 }
 
 const (
-	DescriptionLanguagePrefix = "rp-prefix"
-	DescriptionLanguageSuffix = "rp-suffix"
+	DescriptionLanguagePrefix  = "rp-prefix"
+	DescriptionLanguageSuffix  = "rp-suffix"
+	DescriptionLanguageVersion = "rp-version"
 )
 
 const (
-	MarkdownSectionChangelog = "changelog"
+	MarkdownSectionChangelog    = "changelog"
+	MarkdownSectionReleaseNotes = "release-notes"
 )
 
 const (
@@ -111,25 +116,48 @@ func (pr *ReleasePullRequest) parseDescription(overrides ReleaseOverrides) (Rele
 	err := markdown.WalkAST(source,
 		markdown.GetCodeBlockText(source, DescriptionLanguagePrefix, &overrides.Prefix, nil),
 		markdown.GetCodeBlockText(source, DescriptionLanguageSuffix, &overrides.Suffix, nil),
+		markdown.GetCodeBlockText(source, DescriptionLanguageVersion, &overrides.Version, nil),
 	)
 	if err != nil {
 		return ReleaseOverrides{}, err
 	}
 
+	overrides.Version = strings.TrimSpace(overrides.Version)
+
 	return overrides, nil
 }
 
 func (pr *ReleasePullRequest) ChangelogText() (string, error) {
+	return pr.sectionText(MarkdownSectionChangelog)
+}
+
+// ReleaseNotesText returns the text rendered from the release template. It is empty if no release template is
+// configured, in that case the ChangelogText should be used for the release.
+func (pr *ReleasePullRequest) ReleaseNotesText() (string, error) {
+	return pr.sectionText(MarkdownSectionReleaseNotes)
+}
+
+// ReleaseText returns the description of the release. This is the ReleaseNotesText if it exists, and the ChangelogText
+// otherwise.
+func (pr *ReleasePullRequest) ReleaseText() (string, error) {
+	text, err := pr.ReleaseNotesText()
+	if err != nil || text != "" {
+		return text, err
+	}
+
+	return pr.ChangelogText()
+}
+
+func (pr *ReleasePullRequest) sectionText(name string) (string, error) {
 	source := []byte(pr.Description)
 
 	var sectionText string
-	err := markdown.WalkAST(source, markdown.GetSectionText(source, MarkdownSectionChangelog, &sectionText))
+	err := markdown.WalkAST(source, markdown.GetSectionText(source, name, &sectionText))
 	if err != nil {
 		return "", err
 	}
 
 	return sectionText, nil
-
 }
 
 func (pr *ReleasePullRequest) SetTitle(branch, version string) {
@@ -145,11 +173,14 @@ func (pr *ReleasePullRequest) Version() (string, error) {
 	return matches[2], nil
 }
 
-func (pr *ReleasePullRequest) SetDescription(changelogEntry string, overrides ReleaseOverrides) error {
+// SetDescription renders the description with the changelog entry and the overrides. The releaseNotes are only added
+// if they are not empty, see ReleaseNotesText.
+func (pr *ReleasePullRequest) SetDescription(changelogEntry, releaseNotes string, overrides ReleaseOverrides) error {
 	var description bytes.Buffer
 	err := releasePRTemplate.Execute(&description, map[string]any{
-		"Changelog": changelogEntry,
-		"Overrides": overrides,
+		"Changelog":    changelogEntry,
+		"ReleaseNotes": releaseNotes,
+		"Overrides":    overrides,
 	})
 	if err != nil {
 		return err
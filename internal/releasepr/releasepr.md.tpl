@@ -9,6 +9,15 @@
 
 If you want to modify the proposed release, add you overrides here. You can learn more about the options in the docs.
 
+## Version
+
+To release a specific version instead of the calculated one, add it here. It must be greater than the latest release.
+
+~~~~rp-version
+{{- if .Overrides.Version }}
+{{ .Overrides.Version }}{{ end }}
+~~~~
+
 ## Release Notes
 
 ### Prefix / Start
@@ -28,5 +37,13 @@ This will be added to the end of the release notes.
 {{- if .Overrides.Suffix }}
 {{ .Overrides.Suffix }}{{ end }}
 ~~~~
+{{ if .ReleaseNotes }}
+### Release
+
+This text is used for the release. It is generated from the release template, changes made here are overwritten.
 
+<!-- section-start release-notes -->
+{{ .ReleaseNotes }}
+<!-- section-end release-notes -->
+{{ end }}
 </details>
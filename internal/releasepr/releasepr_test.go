@@ -58,6 +58,18 @@ func TestReleasePullRequest_GetOverrides(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "version in description",
+			pr: ReleasePullRequest{
+				PullRequest: git.PullRequest{
+					Description: "~~~~rp-version\n  2.0.0\n~~~~\n",
+				},
+			},
+			want: ReleaseOverrides{
+				Version: "2.0.0",
+			},
+			wantErr: assert.NoError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -107,6 +119,42 @@ func TestReleasePullRequest_ChangelogText(t *testing.T) {
 	}
 }
 
+func TestReleasePullRequest_ReleaseNotesText(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "no section",
+			description: testdata.MustReadFileString(t, "description-no-overrides.txt"),
+			want:        "",
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "with section",
+			description: testdata.MustReadFileString(t, "description-release-notes.txt"),
+			want:        "Release notes from the template\n",
+			wantErr:     assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &ReleasePullRequest{
+				PullRequest: git.PullRequest{
+					Description: tt.description,
+				},
+			}
+			got, err := pr.ReleaseNotesText()
+			if !tt.wantErr(t, err, "ReleaseNotesText()") {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "ReleaseNotesText()")
+		})
+	}
+}
+
 func TestReleasePullRequest_SetTitle(t *testing.T) {
 	type args struct {
 		branch  string
@@ -155,6 +203,7 @@ func TestReleasePullRequest_SetDescription(t *testing.T) {
 	tests := []struct {
 		name           string
 		changelogEntry string
+		releaseNotes   string
 		overrides      ReleaseOverrides
 		want           string
 		wantErr        assert.ErrorAssertionFunc
@@ -170,17 +219,26 @@ func TestReleasePullRequest_SetDescription(t *testing.T) {
 			name:           "existing overrides",
 			changelogEntry: `## v1.0.0`,
 			overrides: ReleaseOverrides{
-				Prefix: testdata.MustReadFileString(t, "prefix.txt"),
-				Suffix: testdata.MustReadFileString(t, "suffix.txt"),
+				Prefix:  testdata.MustReadFileString(t, "prefix.txt"),
+				Suffix:  testdata.MustReadFileString(t, "suffix.txt"),
+				Version: "2.0.0",
 			},
 			want:    testdata.MustReadFileString(t, "description-overrides.txt"),
 			wantErr: assert.NoError,
 		},
+		{
+			name:           "release notes",
+			changelogEntry: `## v1.0.0`,
+			releaseNotes:   "Release notes from the template",
+			overrides:      ReleaseOverrides{},
+			want:           testdata.MustReadFileString(t, "description-release-notes.txt"),
+			wantErr:        assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pr := &ReleasePullRequest{}
-			err := pr.SetDescription(tt.changelogEntry, tt.overrides)
+			err := pr.SetDescription(tt.changelogEntry, tt.releaseNotes, tt.overrides)
 			if !tt.wantErr(t, err) {
 				return
 			}
@@ -189,3 +247,9 @@ func TestReleasePullRequest_SetDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelFromName(t *testing.T) {
+	assert.Equal(t, LabelReleasePending, LabelFromName("rp-release::pending"))
+	assert.Equal(t, LabelNextVersionTypeRC, LabelFromName("rp-next-version::rc"))
+	assert.Equal(t, Label{Name: "release"}, LabelFromName("release"))
+}
@@ -0,0 +1,176 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	CargoManifestFile = "Cargo.toml"
+	CargoLockFile     = "Cargo.lock"
+)
+
+var (
+	// cargoDependencyTableRegex matches the dependency tables of a manifest, e.g. `dependencies`,
+	// `target.'cfg(unix)'.dev-dependencies` or `workspace.dependencies`. Tables of a single dependency like
+	// `dependencies.foo` have the name of the dependency in the first group.
+	cargoDependencyTableRegex = regexp.MustCompile(`^(?:target\..+\.|workspace\.)?(?:dev-|build-)?dependencies(?:\.([\w-]+|"[^"]*"|'[^']*'))?$`)
+	// cargoVersionRequirementRegex matches the operator of a version requirement like `=1.2.3` or `^1.2`.
+	cargoVersionRequirementRegex = regexp.MustCompile(`^[=^~<>]*\s*`)
+)
+
+// Cargo creates an updater that modifies the version of Rust crates in Cargo.toml. If the manifest is the root of a
+// workspace, the version of all members, `workspace.package.version` and the versions of the path dependencies
+// between the members are updated as well. The entries of the crates in Cargo.lock are updated if the file exists.
+func Cargo() Updater {
+	return &cargo{}
+}
+
+type cargo struct {
+	// crates are the names of the packages in the workspace, they are collected in FindFiles.
+	crates []string
+}
+
+type cargoManifest struct {
+	Package *struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+func (c *cargo) Files() []string {
+	return []string{CargoManifestFile}
+}
+
+func (c *cargo) CreateNewFiles() bool {
+	return false
+}
+
+// FindFiles returns the manifests of the workspace members and Cargo.lock.
+func (c *cargo) FindFiles(fsys fs.FS) ([]string, error) {
+	c.crates = nil
+
+	manifest, err := readCargoManifest(fsys, CargoManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Package != nil {
+		c.crates = append(c.crates, manifest.Package.Name)
+	}
+
+	var files []string
+	if manifest.Workspace != nil {
+		for _, member := range manifest.Workspace.Members {
+			matches, err := fs.Glob(fsys, path.Join(member, CargoManifestFile))
+			if err != nil {
+				return nil, fmt.Errorf("invalid workspace member %q: %w", member, err)
+			}
+
+			for _, file := range matches {
+				excluded := slices.ContainsFunc(manifest.Workspace.Exclude, func(exclude string) bool {
+					return path.Clean(exclude) == path.Dir(file)
+				})
+				if file == CargoManifestFile || excluded {
+					continue
+				}
+
+				memberManifest, err := readCargoManifest(fsys, file)
+				if err != nil {
+					return nil, err
+				}
+				if memberManifest.Package != nil {
+					c.crates = append(c.crates, memberManifest.Package.Name)
+				}
+
+				files = append(files, file)
+			}
+		}
+	}
+
+	if _, err = fs.Stat(fsys, CargoLockFile); err == nil {
+		files = append(files, CargoLockFile)
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+func readCargoManifest(fsys fs.FS, file string) (cargoManifest, error) {
+	var manifest cargoManifest
+
+	content, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return manifest, nil
+		}
+		return manifest, err
+	}
+
+	if err = toml.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	return manifest, nil
+}
+
+func (c *cargo) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		// Cargo does not allow the "v" prefix
+		version := strings.TrimPrefix(info.Version, "v")
+		setVersion := func(string) string { return version }
+		setRequirement := func(requirement string) string {
+			return cargoVersionRequirementRegex.FindString(requirement) + version
+		}
+
+		tables := splitTOMLTables(content)
+		for _, table := range tables {
+			switch {
+			case table.name == "package" && table.array:
+				// Cargo.lock, packages from a registry or git have a source
+				name, _ := table.stringValue("name")
+				if slices.Contains(c.crates, name) && !table.hasKey("source") {
+					table.setStringValue("version", setVersion)
+				}
+			case table.name == "package" || table.name == "workspace.package":
+				table.setStringValue("version", setVersion)
+			default:
+				match := cargoDependencyTableRegex.FindStringSubmatch(table.name)
+				if match == nil {
+					continue
+				}
+
+				if match[1] != "" {
+					name := strings.Trim(match[1], `"'`)
+					if renamed, ok := table.stringValue("package"); ok {
+						name = renamed
+					}
+					if slices.Contains(c.crates, name) && table.hasKey("path") {
+						table.setStringValue("version", setRequirement)
+					}
+					continue
+				}
+
+				for name, i := range table.inlineTables() {
+					if renamed, ok := table.inlineStringValue(i, "package"); ok {
+						name = renamed
+					}
+					if slices.Contains(c.crates, name) && table.inlineHasKey(i, "path") {
+						table.setInlineStringValue(i, "version", setRequirement)
+					}
+				}
+			}
+		}
+
+		return joinTOMLTables(tables), nil
+	}
+}
@@ -0,0 +1,180 @@
+package updater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCargoUpdater_Files(t *testing.T) {
+	assert.Equal(t, []string{"Cargo.toml"}, Cargo().Files())
+}
+
+func TestCargoUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Cargo().CreateNewFiles())
+}
+
+func TestCargoUpdater_FindFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		fsys       fstest.MapFS
+		want       []string
+		wantCrates []string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "single crate",
+			fsys: fstest.MapFS{
+				"Cargo.toml": {Data: []byte("[package]\nname = \"foo\"\nversion = \"1.0.0\"\n")},
+			},
+			want:       nil,
+			wantCrates: []string{"foo"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "single crate with lock file",
+			fsys: fstest.MapFS{
+				"Cargo.toml": {Data: []byte("[package]\nname = \"foo\"\nversion = \"1.0.0\"\n")},
+				"Cargo.lock": {Data: []byte("version = 4\n")},
+			},
+			want:       []string{"Cargo.lock"},
+			wantCrates: []string{"foo"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "workspace",
+			fsys: fstest.MapFS{
+				"Cargo.toml":               {Data: []byte("[workspace]\nmembers = [\"crates/*\", \"cli\"]\nexclude = [\"crates/legacy\"]\n\n[workspace.package]\nversion = \"1.0.0\"\n")},
+				"Cargo.lock":               {Data: []byte("version = 4\n")},
+				"cli/Cargo.toml":           {Data: []byte("[package]\nname = \"foo-cli\"\nversion.workspace = true\n")},
+				"crates/core/Cargo.toml":   {Data: []byte("[package]\nname = \"foo-core\"\nversion.workspace = true\n")},
+				"crates/legacy/Cargo.toml": {Data: []byte("[package]\nname = \"foo-legacy\"\nversion = \"0.1.0\"\n")},
+				"crates/README.md":         {Data: []byte("# Crates\n")},
+			},
+			want:       []string{"Cargo.lock", "cli/Cargo.toml", "crates/core/Cargo.toml"},
+			wantCrates: []string{"foo-core", "foo-cli"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "workspace with root package",
+			fsys: fstest.MapFS{
+				"Cargo.toml":        {Data: []byte("[package]\nname = \"foo\"\nversion = \"1.0.0\"\n\n[workspace]\nmembers = [\".\", \"macros\"]\n")},
+				"macros/Cargo.toml": {Data: []byte("[package]\nname = \"foo-macros\"\nversion = \"1.0.0\"\n")},
+			},
+			want:       []string{"macros/Cargo.toml"},
+			wantCrates: []string{"foo", "foo-macros"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "missing manifest",
+			fsys:    fstest.MapFS{},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid manifest",
+			fsys: fstest.MapFS{
+				"Cargo.toml": {Data: []byte("[package\n")},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &cargo{}
+			got, err := u.FindFiles(tt.fsys)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCrates, u.crates)
+		})
+	}
+}
+
+func TestCargoUpdater_Update(t *testing.T) {
+	u := &cargo{crates: []string{"foo", "foo-core", "foo-cli"}}
+
+	tests := []updaterTestCase{
+		{
+			name:    "package",
+			content: "[package]\nname = \"foo\" # the crate\nversion = \"1.0.0\" # managed by releaser-pleaser\nedition = \"2021\"\n\n[dependencies]\nserde = { version = \"1.0.0\", features = [\"derive\"] }\n",
+			info: ReleaseInfo{
+				Version: "v2.0.5",
+			},
+			want:    "[package]\nname = \"foo\" # the crate\nversion = \"2.0.5\" # managed by releaser-pleaser\nedition = \"2021\"\n\n[dependencies]\nserde = { version = \"1.0.0\", features = [\"derive\"] }\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "package with inherited version",
+			content: "[package]\nname = \"foo-cli\"\nversion.workspace = true\n",
+			info: ReleaseInfo{
+				Version: "v2.0.5",
+			},
+			want:    "[package]\nname = \"foo-cli\"\nversion.workspace = true\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "workspace",
+			content: "[workspace]\nmembers = [\n  \"crates/*\",\n]\n\n[workspace.package]\nversion = \"1.0.0\"\n\n[workspace.dependencies]\nfoo-core = { path = \"crates/core\", version = \"=1.0.0\" }\nserde = \"1.0.0\"\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "[workspace]\nmembers = [\n  \"crates/*\",\n]\n\n[workspace.package]\nversion = \"1.1.0\"\n\n[workspace.dependencies]\nfoo-core = { path = \"crates/core\", version = \"=1.1.0\" }\nserde = \"1.0.0\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "path dependencies",
+			content: "[package]\nname = \"foo-cli\"\nversion = \"1.0.0\"\n\n[dependencies]\nfoo-core = { version = \"1.0.0\", path = \"../crates/core\" }\ncore = { package = \"foo-core\", path = \"../crates/core\", version = \"^1.0\" }\nbar = { version = \"1.0.0\", path = \"../bar\" }\nfoo = { version = \"1.0.0\" }\n\n[dev-dependencies.foo]\npath = \"..\"\nversion = \"1.0.0\"\n\n[target.'cfg(unix)'.build-dependencies]\nfoo-core = { path = \"../crates/core\", version = \"1.0.0\" }\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "[package]\nname = \"foo-cli\"\nversion = \"1.1.0\"\n\n[dependencies]\nfoo-core = { version = \"1.1.0\", path = \"../crates/core\" }\ncore = { package = \"foo-core\", path = \"../crates/core\", version = \"^1.1.0\" }\nbar = { version = \"1.0.0\", path = \"../bar\" }\nfoo = { version = \"1.0.0\" }\n\n[dev-dependencies.foo]\npath = \"..\"\nversion = \"1.1.0\"\n\n[target.'cfg(unix)'.build-dependencies]\nfoo-core = { path = \"../crates/core\", version = \"1.1.0\" }\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "lock file",
+			content: "# This file is automatically @generated by Cargo.\nversion = 4\n\n[[package]]\nname = \"foo\"\nversion = \"1.0.0\"\ndependencies = [\n \"serde\",\n]\n\n[[package]]\nname = \"foo\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n",
+			info: ReleaseInfo{
+				Version: "v2.0.0",
+			},
+			want:    "# This file is automatically @generated by Cargo.\nversion = 4\n\n[[package]]\nname = \"foo\"\nversion = \"2.0.0\"\ndependencies = [\n \"serde\",\n]\n\n[[package]]\nname = \"foo\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "crlf line endings",
+			content: "[package]\r\nname = \"foo\"\r\nversion = \"1.0.0\"\r\n",
+			info: ReleaseInfo{
+				Version: "v1.0.1",
+			},
+			want:    "[package]\r\nname = \"foo\"\r\nversion = \"1.0.1\"\r\n",
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, u, tt)
+		})
+	}
+}
+
+func TestCargoUpdater_FindFilesAndUpdate(t *testing.T) {
+	u := Cargo()
+	finder, ok := u.(FileFinder)
+	require.True(t, ok)
+
+	_, err := finder.FindFiles(fstest.MapFS{
+		"Cargo.toml": {Data: []byte("[package]\nname = \"foo\"\nversion = \"1.0.0\"\n")},
+	})
+	require.NoError(t, err)
+
+	got, err := u.Update(ReleaseInfo{Version: "1.1.0"})("[[package]]\nname = \"foo\"\nversion = \"1.0.0\"\n")
+	require.NoError(t, err)
+	assert.Equal(t, "[[package]]\nname = \"foo\"\nversion = \"1.1.0\"\n", got)
+}
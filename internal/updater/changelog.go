@@ -15,14 +15,22 @@ var (
 )
 
 func Changelog() Updater {
-	return changelog{}
+	return ChangelogAt(ChangelogFile)
+}
+
+// ChangelogAt creates a changelog updater that writes to file instead of the default CHANGELOG.md.
+func ChangelogAt(file string) Updater {
+	return changelog{
+		file: file,
+	}
 }
 
 type changelog struct {
+	file string
 }
 
 func (c changelog) Files() []string {
-	return []string{ChangelogFile}
+	return []string{c.file}
 }
 
 func (c changelog) CreateNewFiles() bool {
@@ -33,7 +41,7 @@ func (c changelog) Update(info ReleaseInfo) func(content string) (string, error)
 	return func(content string) (string, error) {
 		headerIndex := ChangelogUpdaterHeaderRegex.FindStringIndex(content)
 		if headerIndex == nil && len(content) != 0 {
-			return "", fmt.Errorf("unexpected format of CHANGELOG.md, header does not match")
+			return "", fmt.Errorf("unexpected format of %s, header does not match", c.file)
 		}
 		if headerIndex != nil {
 			// Remove the header from the content
@@ -10,6 +10,10 @@ func TestChangelogUpdater_Files(t *testing.T) {
 	assert.Equal(t, []string{"CHANGELOG.md"}, Changelog().Files())
 }
 
+func TestChangelogAtUpdater_Files(t *testing.T) {
+	assert.Equal(t, []string{"docs/CHANGELOG.md"}, ChangelogAt("docs/CHANGELOG.md").Files())
+}
+
 func TestChangelogUpdater_CreateNewFiles(t *testing.T) {
 	assert.True(t, Changelog().CreateNewFiles())
 }
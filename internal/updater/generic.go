@@ -1,35 +1,225 @@
 package updater
 
 import (
+	"fmt"
+	"io/fs"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/apricote/releaser-pleaser/internal/glob"
+)
+
+const (
+	// GenericMarker is the comment that marks the versions that are updated by the generic updater.
+	GenericMarker = "x-releaser-pleaser-version"
+	// GenericBlockStartMarker and GenericBlockEndMarker surround a block of lines in which all versions are updated.
+	GenericBlockStartMarker = GenericMarker + "-start"
+	GenericBlockEndMarker   = GenericMarker + "-end"
 )
 
-var GenericUpdaterSemVerRegex = regexp.MustCompile(`\d+\.\d+\.\d+(-[\w.]+)?(.*x-releaser-pleaser-version)`)
+var (
+	GenericUpdaterSemVerRegex = regexp.MustCompile(`\d+\.\d+\.\d+(-[\w.]+)?(.*x-releaser-pleaser-version)`)
 
+	genericBlockSemVerRegex   = regexp.MustCompile(`\d+\.\d+\.\d+(-[\w.]+)?`)
+	genericBlockTemplateRegex = regexp.MustCompile("template=(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+)
+
+// Generic creates an updater that replaces the versions followed by GenericMarker, and all versions in the lines
+// between GenericBlockStartMarker and GenericBlockEndMarker. If the start marker has a `template="..."` attribute, the
+// lines are replaced by the rendered Go template instead, see VersionTemplateData.
+//
+// The files may be paths or glob patterns with `**` to match any number of directories, e.g. `docs/**/*.md`. Files
+// that match a pattern but do not contain the marker are skipped.
 func Generic(files []string) Updater {
 	return generic{
 		files: files,
 	}
 }
 
+// GenericScan creates a generic updater that additionally updates every file in the repository that contains
+// GenericMarker. Files and directories that match one of the ignore patterns are skipped, patterns without a "/" match
+// the name at any depth, e.g. `node_modules` or `*.min.js`.
+func GenericScan(files []string, ignore []string) Updater {
+	return generic{
+		files:  files,
+		scan:   true,
+		ignore: ignore,
+	}
+}
+
 type generic struct {
-	files []string
+	files  []string
+	scan   bool
+	ignore []string
 }
 
+// Files returns the files that are not glob patterns, these have to exist.
 func (g generic) Files() []string {
-	return g.files
+	return slices.DeleteFunc(slices.Clone(g.files), isGlobPattern)
 }
 
 func (g generic) CreateNewFiles() bool {
 	return false
 }
 
+// FindFiles returns the files that match the glob patterns, or all files if scan is enabled, that contain the marker.
+func (g generic) FindFiles(fsys fs.FS) ([]string, error) {
+	patterns := slices.DeleteFunc(slices.Clone(g.files), func(file string) bool { return !isGlobPattern(file) })
+	for _, pattern := range slices.Concat(patterns, g.ignore) {
+		if err := glob.Validate(pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	if len(patterns) == 0 && !g.scan {
+		return nil, nil
+	}
+
+	files := []string{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		if (d.IsDir() && d.Name() == ".git") || g.ignored(name) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || slices.Contains(g.files, name) {
+			return nil
+		}
+
+		matched := g.scan
+		for _, pattern := range patterns {
+			if glob.Match(pattern, name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(content), GenericMarker) {
+			files = append(files, name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (g generic) ignored(name string) bool {
+	for _, pattern := range g.ignore {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+		if glob.Match(pattern, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (g generic) Update(info ReleaseInfo) func(content string) (string, error) {
 	return func(content string) (string, error) {
 		// We strip the "v" prefix to avoid adding/removing it from the users input.
 		version := strings.TrimPrefix(info.Version, "v")
 
+		content, err := updateGenericBlocks(content, version)
+		if err != nil {
+			return "", err
+		}
+
 		return GenericUpdaterSemVerRegex.ReplaceAllString(content, version+"${2}"), nil
 	}
 }
+
+// updateGenericBlocks updates the lines between the block markers. The marker lines themselves are kept as is.
+func updateGenericBlocks(content, version string) (string, error) {
+	if !strings.Contains(content, GenericBlockStartMarker) && !strings.Contains(content, GenericBlockEndMarker) {
+		return content, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+
+	var updated strings.Builder
+	start := -1
+	for i, line := range lines {
+		switch {
+		case strings.Contains(line, GenericBlockStartMarker):
+			if start >= 0 {
+				return "", fmt.Errorf("line %d: %s in block started in line %d", i+1, GenericBlockStartMarker, start+1)
+			}
+			start = i
+			updated.WriteString(line)
+		case strings.Contains(line, GenericBlockEndMarker):
+			if start < 0 {
+				return "", fmt.Errorf("line %d: %s without %s", i+1, GenericBlockEndMarker, GenericBlockStartMarker)
+			}
+
+			block, err := updateGenericBlock(lines[start], strings.Join(lines[start+1:i], ""), version)
+			if err != nil {
+				return "", fmt.Errorf("line %d: %w", start+1, err)
+			}
+			updated.WriteString(block)
+			updated.WriteString(line)
+			start = -1
+		case start < 0:
+			updated.WriteString(line)
+		}
+	}
+	if start >= 0 {
+		return "", fmt.Errorf("line %d: %s without %s", start+1, GenericBlockStartMarker, GenericBlockEndMarker)
+	}
+
+	return updated.String(), nil
+}
+
+// updateGenericBlock renders the template from the start marker line or replaces all versions in the block.
+func updateGenericBlock(startLine, block, version string) (string, error) {
+	match := genericBlockTemplateRegex.FindStringSubmatch(startLine)
+	if match == nil {
+		return genericBlockSemVerRegex.ReplaceAllString(block, version), nil
+	}
+
+	text, err := strconv.Unquote(match[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid block template %s: %w", match[1], err)
+	}
+	tpl, err := template.New("block").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid block template: %w", err)
+	}
+
+	var rendered strings.Builder
+	if err = tpl.Execute(&rendered, NewVersionTemplateData(version)); err != nil {
+		return "", fmt.Errorf("failed to render block template: %w", err)
+	}
+	if rendered.Len() > 0 && !strings.HasSuffix(rendered.String(), "\n") {
+		rendered.WriteString("\n")
+	}
+
+	return rendered.String(), nil
+}
+
+func isGlobPattern(file string) bool {
+	return strings.ContainsAny(file, "*?[")
+}
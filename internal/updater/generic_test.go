@@ -2,12 +2,77 @@ package updater
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGenericUpdater_Files(t *testing.T) {
 	assert.Equal(t, []string{"foo.bar", "version.txt"}, Generic([]string{"foo.bar", "version.txt"}).Files())
+	assert.Equal(t, []string{"version.txt"}, Generic([]string{"docs/**/*.md", "version.txt"}).Files())
+}
+
+func TestGenericUpdater_FindFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"version.txt":                  {Data: []byte("1.0.0 // x-releaser-pleaser-version")},
+		"README.md":                    {Data: []byte("Install v1.0.0 <!-- x-releaser-pleaser-version -->")},
+		"docs/index.md":                {Data: []byte("# Docs")},
+		"docs/guides/install.md":       {Data: []byte("Install v1.0.0 <!-- x-releaser-pleaser-version -->")},
+		"docs/guides/install.txt":      {Data: []byte("Install v1.0.0 x-releaser-pleaser-version")},
+		"node_modules/foo/README.md":   {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+		"dist/app.min.js":              {Data: []byte("v1.0.0 x-releaser-pleaser-version")},
+		".git/COMMIT_EDITMSG":          {Data: []byte("x-releaser-pleaser-version")},
+		"internal/version/version.go":  {Data: []byte(`const Version = "v1.0.0" // x-releaser-pleaser-version`)},
+		"internal/version/version2.go": {Data: []byte(`const Version = "v1.0.0"`)},
+	}
+
+	tests := []struct {
+		name    string
+		updater Updater
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "files",
+			updater: Generic([]string{"version.txt"}),
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "glob",
+			updater: Generic([]string{"docs/**/*.md", "internal/*/*.go", "version.txt"}),
+			want:    []string{"docs/guides/install.md", "internal/version/version.go"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "glob without matches",
+			updater: Generic([]string{"*.yaml"}),
+			want:    []string{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid glob",
+			updater: Generic([]string{"docs/[.md"}),
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "scan",
+			updater: GenericScan([]string{"version.txt"}, []string{"node_modules", "dist/*.js", "docs/**/*.txt"}),
+			want:    []string{"README.md", "docs/guides/install.md", "internal/version/version.go"},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.updater.(FileFinder).FindFiles(fsys)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
 
 func TestGenericUpdater_CreateNewFiles(t *testing.T) {
@@ -52,6 +117,78 @@ func TestGenericUpdater_Update(t *testing.T) {
 			want:    "version: v1.2.0 => Awesome, isnt it? x-releaser-pleaser-version foobar",
 			wantErr: assert.NoError,
 		},
+		{
+			name:    "block",
+			content: "# Install\n\n<!-- x-releaser-pleaser-version-start -->\n```sh\ncurl -L https://example.com/v1.0.0/install.sh | sh -s -- --version 1.0.0-rc.1\n```\n<!-- x-releaser-pleaser-version-end -->\n\nSince v0.9.0\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "# Install\n\n<!-- x-releaser-pleaser-version-start -->\n```sh\ncurl -L https://example.com/v1.2.0/install.sh | sh -s -- --version 1.2.0\n```\n<!-- x-releaser-pleaser-version-end -->\n\nSince v0.9.0\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "block with template",
+			content: "<!-- x-releaser-pleaser-version-start template=\"go install example.com/foo@v{{.Version}}\\nsee https://example.com/docs/v{{.Major}}/\" -->\ngo install example.com/foo@latest\n<!-- x-releaser-pleaser-version-end -->\n",
+			info: ReleaseInfo{
+				Version: "v2.1.0",
+			},
+			want:    "<!-- x-releaser-pleaser-version-start template=\"go install example.com/foo@v{{.Version}}\\nsee https://example.com/docs/v{{.Major}}/\" -->\ngo install example.com/foo@v2.1.0\nsee https://example.com/docs/v2/\n<!-- x-releaser-pleaser-version-end -->\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "block with raw template",
+			content: "# x-releaser-pleaser-version-start template=`VERSION={{.Major}}.{{.Minor}}`\nVERSION=1.0\n# x-releaser-pleaser-version-end\nOTHER=1.0.0\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "# x-releaser-pleaser-version-start template=`VERSION={{.Major}}.{{.Minor}}`\nVERSION=1.2\n# x-releaser-pleaser-version-end\nOTHER=1.0.0\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "multiple blocks and line marker",
+			content: "<!-- x-releaser-pleaser-version-start -->\nv1.0.0\n<!-- x-releaser-pleaser-version-end -->\nv1.0.0 <!-- x-releaser-pleaser-version -->\n<!-- x-releaser-pleaser-version-start -->\nv1.0.0\n<!-- x-releaser-pleaser-version-end -->",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "<!-- x-releaser-pleaser-version-start -->\nv1.2.0\n<!-- x-releaser-pleaser-version-end -->\nv1.2.0 <!-- x-releaser-pleaser-version -->\n<!-- x-releaser-pleaser-version-start -->\nv1.2.0\n<!-- x-releaser-pleaser-version-end -->",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "block without end",
+			content: "<!-- x-releaser-pleaser-version-start -->\nv1.0.0\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "block without start",
+			content: "v1.0.0\n<!-- x-releaser-pleaser-version-end -->\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "nested blocks",
+			content: "<!-- x-releaser-pleaser-version-start -->\n<!-- x-releaser-pleaser-version-start -->\n<!-- x-releaser-pleaser-version-end -->\n<!-- x-releaser-pleaser-version-end -->\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "block with invalid template",
+			content: "<!-- x-releaser-pleaser-version-start template=\"{{.Version\" -->\nv1.0.0\n<!-- x-releaser-pleaser-version-end -->\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,69 @@
+package updater
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+// GradleFiles are the files that are updated by the gradle updater if they exist.
+var GradleFiles = []string{"gradle.properties", "build.gradle", "build.gradle.kts"}
+
+var (
+	// gradlePropertiesVersionRegex matches `version=1.2.3` in gradle.properties. The value must start with a digit,
+	// so that assignments in build scripts like `version = project.findProperty("foo")` are not matched.
+	gradlePropertiesVersionRegex = regexp.MustCompile(`(?m)^(\s*version\s*[=:]\s*)(\d[^\s"']*)(\s*)$`)
+	// gradleBuildVersionRegex matches `version = "1.2.3"` in build.gradle(.kts) and `version '1.2.3'` in Groovy.
+	gradleBuildVersionRegex = regexp.MustCompile(`(?m)^(\s*version\s*=?\s*["'])([^"'$]*)(["'])`)
+)
+
+// Gradle creates an updater that modifies the project version in gradle.properties, build.gradle and build.gradle.kts.
+// With nextSnapshot, the next snapshot version is set after the release, see NextSnapshotVersion.
+func Gradle(nextSnapshot bool) Updater {
+	g := gradle{}
+	if nextSnapshot {
+		return snapshot{g}
+	}
+
+	return g
+}
+
+type gradle struct{}
+
+// Files is empty, the existing GradleFiles are returned from FindFiles.
+func (g gradle) Files() []string {
+	return nil
+}
+
+func (g gradle) CreateNewFiles() bool {
+	return false
+}
+
+func (g gradle) FindFiles(fsys fs.FS) ([]string, error) {
+	var files []string
+	for _, file := range GradleFiles {
+		_, err := fs.Stat(fsys, file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func (g gradle) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		version := strings.TrimPrefix(info.Version, "v")
+
+		content = gradlePropertiesVersionRegex.ReplaceAllString(content, "${1}"+version+"${3}")
+		content = gradleBuildVersionRegex.ReplaceAllString(content, "${1}"+version+"${3}")
+
+		return content, nil
+	}
+}
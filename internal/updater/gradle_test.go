@@ -0,0 +1,93 @@
+package updater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGradleUpdater_Files(t *testing.T) {
+	assert.Empty(t, Gradle(false).Files())
+}
+
+func TestGradleUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Gradle(false).CreateNewFiles())
+}
+
+func TestGradleUpdater_FindFiles(t *testing.T) {
+	got, err := gradle{}.FindFiles(fstest.MapFS{
+		"gradle.properties":    {Data: []byte("version=1.0.0\n")},
+		"build.gradle.kts":     {Data: []byte("version = \"1.0.0\"\n")},
+		"app/build.gradle.kts": {Data: []byte("plugins {}\n")},
+		"settings.gradle.kts":  {Data: []byte("rootProject.name = \"foo\"\n")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gradle.properties", "build.gradle.kts"}, got)
+}
+
+func TestGradleUpdater_Update(t *testing.T) {
+	tests := []updaterTestCase{
+		{
+			name:    "gradle.properties",
+			content: "# Project\ngroup=com.example\nversion=1.0.1-SNAPSHOT\norg.gradle.jvmargs=-Xmx2g\n",
+			info: ReleaseInfo{
+				Version: "v1.0.1",
+			},
+			want:    "# Project\ngroup=com.example\nversion=1.0.1\norg.gradle.jvmargs=-Xmx2g\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "gradle.properties with colon",
+			content: "version : 1.0.0\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "version : 1.1.0\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "build.gradle.kts",
+			content: "plugins {\n    kotlin(\"jvm\") version \"2.0.0\"\n}\n\ngroup = \"com.example\"\nversion = \"1.0.1-SNAPSHOT\" // managed by releaser-pleaser\n\nkotlin {\n    jvmToolchain(21)\n}\n",
+			info: ReleaseInfo{
+				Version: "v1.0.1",
+			},
+			want:    "plugins {\n    kotlin(\"jvm\") version \"2.0.0\"\n}\n\ngroup = \"com.example\"\nversion = \"1.0.1\" // managed by releaser-pleaser\n\nkotlin {\n    jvmToolchain(21)\n}\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "build.gradle",
+			content: "allprojects {\n    version '1.0.0'\n}\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "allprojects {\n    version '1.1.0'\n}\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "version from property",
+			content: "version = project.findProperty(\"releaseVersion\")\nversion = \"${major}.${minor}\"\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "version = project.findProperty(\"releaseVersion\")\nversion = \"${major}.${minor}\"\n",
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, Gradle(false), tt)
+		})
+	}
+}
+
+func TestGradleUpdater_UpdateAfterRelease(t *testing.T) {
+	u, ok := Gradle(true).(PostReleaseUpdater)
+	require.True(t, ok)
+
+	got, err := u.UpdateAfterRelease(ReleaseInfo{Version: "1.0.1"})("version=1.0.1\n")
+	require.NoError(t, err)
+	assert.Equal(t, "version=1.0.2-SNAPSHOT\n", got)
+}
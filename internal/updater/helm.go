@@ -0,0 +1,94 @@
+package updater
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const HelmChartFile = "Chart.yaml"
+
+// DefaultHelmCharts are the patterns of the Chart.yaml files that are updated if no charts are configured. Helm puts
+// the dependencies of a chart into `charts/`, so subdirectories are only searched if they are configured.
+var DefaultHelmCharts = []string{HelmChartFile}
+
+var (
+	// The fields are only matched without indentation, so that the versions of the dependencies are not changed.
+	helmVersionRegex    = regexp.MustCompile(`(?m)^(version:\s*["']?)([^"'\s#]+)(["']?)`)
+	helmAppVersionRegex = regexp.MustCompile(`(?m)^(appVersion:\s*["']?)([^"'\s#]+)(["']?)`)
+)
+
+// Helm creates an updater that modifies the `version` of Helm charts, and the `appVersion` if appVersion is true. The
+// charts are paths or glob patterns of Chart.yaml files, if none are passed DefaultHelmCharts are used.
+func Helm(charts []string, appVersion bool) Updater {
+	return helm{
+		charts:     charts,
+		appVersion: appVersion,
+	}
+}
+
+type helm struct {
+	charts     []string
+	appVersion bool
+}
+
+// Files is empty, all charts are returned from FindFiles.
+func (h helm) Files() []string {
+	return nil
+}
+
+func (h helm) CreateNewFiles() bool {
+	return false
+}
+
+// FindFiles returns the Chart.yaml files that match the configured patterns. Every configured pattern must match at
+// least one file.
+func (h helm) FindFiles(fsys fs.FS) ([]string, error) {
+	patterns := h.charts
+	if len(patterns) == 0 {
+		patterns = DefaultHelmCharts
+	}
+
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chart pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 && len(h.charts) > 0 {
+			return nil, fmt.Errorf("chart pattern %q did not match any files", pattern)
+		}
+
+		files = append(files, matches...)
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+func (h helm) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		// Helm requires SemVer 2 without the "v" prefix for the chart version
+		version := strings.TrimPrefix(info.Version, "v")
+
+		content = helmVersionRegex.ReplaceAllString(content, "${1}"+version+"${3}")
+
+		if h.appVersion {
+			content = helmAppVersionRegex.ReplaceAllStringFunc(content, func(match string) string {
+				groups := helmAppVersionRegex.FindStringSubmatch(match)
+
+				// The appVersion is free-form, keep the "v" prefix if the app uses it
+				appVersion := version
+				if strings.HasPrefix(groups[2], "v") {
+					appVersion = "v" + version
+				}
+
+				return groups[1] + appVersion + groups[3]
+			})
+		}
+
+		return content, nil
+	}
+}
@@ -0,0 +1,147 @@
+package updater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelmUpdater_Files(t *testing.T) {
+	assert.Empty(t, Helm(nil, false).Files())
+}
+
+func TestHelmUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Helm(nil, false).CreateNewFiles())
+}
+
+func TestHelmUpdater_FindFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Chart.yaml":                  {Data: []byte("version: 1.0.0\n")},
+		"charts/api/Chart.yaml":       {Data: []byte("version: 1.0.0\n")},
+		"charts/worker/Chart.yaml":    {Data: []byte("version: 1.0.0\n")},
+		"deploy/helm/foo/Chart.yaml":  {Data: []byte("version: 1.0.0\n")},
+		"deploy/helm/foo/values.yaml": {Data: []byte("image: foo\n")},
+	}
+
+	tests := []struct {
+		name    string
+		charts  []string
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "default",
+			charts:  nil,
+			want:    []string{"Chart.yaml"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "paths and patterns",
+			charts:  []string{"deploy/helm/*/Chart.yaml", "charts/api/Chart.yaml"},
+			want:    []string{"charts/api/Chart.yaml", "deploy/helm/foo/Chart.yaml"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "no match",
+			charts:  []string{"helm/Chart.yaml"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid pattern",
+			charts:  []string{"charts/[/Chart.yaml"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := helm{charts: tt.charts}.FindFiles(fsys)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHelmUpdater_Update(t *testing.T) {
+	chart := `apiVersion: v2
+name: foo
+# The chart version, managed by releaser-pleaser
+version: 1.0.0 # chart
+appVersion: "1.0.0"
+dependencies:
+  - name: postgresql
+    version: 12.1.0
+    repository: https://charts.bitnami.com/bitnami
+`
+
+	tests := []struct {
+		updaterTestCase
+		appVersion bool
+	}{
+		{
+			updaterTestCase: updaterTestCase{
+				name:    "version",
+				content: chart,
+				info: ReleaseInfo{
+					Version: "v1.1.0",
+				},
+				want: `apiVersion: v2
+name: foo
+# The chart version, managed by releaser-pleaser
+version: 1.1.0 # chart
+appVersion: "1.0.0"
+dependencies:
+  - name: postgresql
+    version: 12.1.0
+    repository: https://charts.bitnami.com/bitnami
+`,
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			updaterTestCase: updaterTestCase{
+				name:    "app version",
+				content: chart,
+				info: ReleaseInfo{
+					Version: "v1.1.0",
+				},
+				want: `apiVersion: v2
+name: foo
+# The chart version, managed by releaser-pleaser
+version: 1.1.0 # chart
+appVersion: "1.1.0"
+dependencies:
+  - name: postgresql
+    version: 12.1.0
+    repository: https://charts.bitnami.com/bitnami
+`,
+				wantErr: assert.NoError,
+			},
+			appVersion: true,
+		},
+		{
+			updaterTestCase: updaterTestCase{
+				name:    "app version with prefix",
+				content: "version: '1.0.0'\nappVersion: v1.0.0\n",
+				info: ReleaseInfo{
+					Version: "v1.1.0-rc.1",
+				},
+				want:    "version: '1.1.0-rc.1'\nappVersion: v1.1.0-rc.1\n",
+				wantErr: assert.NoError,
+			},
+			appVersion: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, Helm(nil, tt.appVersion), tt.updaterTestCase)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package updater
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const MavenPomFile = "pom.xml"
+
+// Maven creates an updater that modifies the project version in pom.xml. In a multi-module reactor, the modules are
+// updated as well: their own version, and the version of their parent if the parent is part of the reactor. With
+// nextSnapshot, the next snapshot version is set after the release, see NextSnapshotVersion.
+func Maven(nextSnapshot bool) Updater {
+	m := &maven{}
+	if nextSnapshot {
+		return snapshot{m}
+	}
+
+	return m
+}
+
+type maven struct {
+	// reactor are the `groupId:artifactId` of all projects in the reactor, they are collected in FindFiles.
+	reactor []string
+}
+
+// pom is the part of pom.xml that the updater needs. The positions are byte offsets of the text of the elements.
+type pom struct {
+	groupID    string
+	artifactID string
+	version    pomValue
+
+	parentGroupID    string
+	parentArtifactID string
+	parentVersion    pomValue
+
+	modules []string
+}
+
+type pomValue struct {
+	value      string
+	start, end int64
+	found      bool
+}
+
+func (p pom) coordinates() string {
+	groupID := p.groupID
+	if groupID == "" {
+		// The groupId is inherited from the parent
+		groupID = p.parentGroupID
+	}
+
+	return groupID + ":" + p.artifactID
+}
+
+func (m *maven) Files() []string {
+	return []string{MavenPomFile}
+}
+
+func (m *maven) CreateNewFiles() bool {
+	return false
+}
+
+// FindFiles returns the pom.xml files of all modules in the reactor, including nested modules.
+func (m *maven) FindFiles(fsys fs.FS) ([]string, error) {
+	var files, reactor []string
+	queue := []string{MavenPomFile}
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		if slices.Contains(files, file) {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && file == MavenPomFile {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		project, err := parsePom(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		reactor = append(reactor, project.coordinates())
+		files = append(files, file)
+
+		for _, module := range project.modules {
+			modulePom := path.Join(path.Dir(file), module)
+			if path.Ext(modulePom) != ".xml" {
+				modulePom = path.Join(modulePom, MavenPomFile)
+			}
+
+			queue = append(queue, modulePom)
+		}
+	}
+
+	m.reactor = reactor
+
+	// The root pom.xml is already returned from Files
+	return files[1:], nil
+}
+
+func (m *maven) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		version := strings.TrimPrefix(info.Version, "v")
+
+		project, err := parsePom(content)
+		if err != nil {
+			return "", err
+		}
+
+		var values []pomValue
+		// Versions from properties like `${revision}` are left alone
+		if project.version.found && !strings.HasPrefix(project.version.value, "${") {
+			values = append(values, project.version)
+		}
+		if project.parentVersion.found && slices.Contains(m.reactor, project.parentGroupID+":"+project.parentArtifactID) {
+			values = append(values, project.parentVersion)
+		}
+
+		// Replace from the end, so that the positions of the other values stay valid
+		slices.SortFunc(values, func(a, b pomValue) int { return int(b.start - a.start) })
+		for _, value := range values {
+			content = content[:value.start] + version + content[value.end:]
+		}
+
+		return content, nil
+	}
+}
+
+// parsePom reads the coordinates and modules of the project in pom.xml. The elements inside of other elements like
+// `<dependencies>` or `<profiles>` are ignored.
+func parsePom(content string) (pom, error) {
+	var project pom
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	var stack []string
+	var text strings.Builder
+	var textStart, offset int64
+	for {
+		// The offset is the end of the previous token and the start of the next one
+		offset = decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return pom{}, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, token.Name.Local)
+			text.Reset()
+			textStart = decoder.InputOffset()
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			// Whitespace around the value is kept
+			raw := text.String()
+			value := pomValue{
+				value: strings.TrimSpace(raw),
+				start: textStart + int64(len(raw)-len(strings.TrimLeftFunc(raw, unicode.IsSpace))),
+				end:   offset - int64(len(raw)-len(strings.TrimRightFunc(raw, unicode.IsSpace))),
+				found: true,
+			}
+
+			switch strings.Join(stack, "/") {
+			case "project/groupId":
+				project.groupID = value.value
+			case "project/artifactId":
+				project.artifactID = value.value
+			case "project/version":
+				project.version = value
+			case "project/parent/groupId":
+				project.parentGroupID = value.value
+			case "project/parent/artifactId":
+				project.parentArtifactID = value.value
+			case "project/parent/version":
+				project.parentVersion = value
+			case "project/modules/module":
+				project.modules = append(project.modules, value.value)
+			}
+
+			stack = stack[:len(stack)-1]
+			text.Reset()
+		}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,239 @@
+package updater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testParentPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.3.0</version>
+  </parent>
+
+  <groupId>com.example</groupId>
+  <artifactId>foo</artifactId>
+  <!-- managed by releaser-pleaser -->
+  <version>1.0.1-SNAPSHOT</version>
+  <packaging>pom</packaging>
+
+  <modules>
+    <module>foo-core</module>
+    <module>foo-cli/pom.xml</module>
+  </modules>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>bar</artifactId>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+const testModulePom = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>foo</artifactId>
+    <version>1.0.1-SNAPSHOT</version>
+  </parent>
+
+  <artifactId>foo-core</artifactId>
+</project>
+`
+
+func TestMavenUpdater_Files(t *testing.T) {
+	assert.Equal(t, []string{"pom.xml"}, Maven(false).Files())
+}
+
+func TestMavenUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Maven(false).CreateNewFiles())
+}
+
+func TestMavenUpdater_FindFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fstest.MapFS
+		want        []string
+		wantReactor []string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "single module",
+			fsys: fstest.MapFS{
+				"pom.xml": {Data: []byte(`<project><groupId>com.example</groupId><artifactId>foo</artifactId><version>1.0.0</version></project>`)},
+			},
+			want:        []string{},
+			wantReactor: []string{"com.example:foo"},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "multi module",
+			fsys: fstest.MapFS{
+				"pom.xml":                  {Data: []byte(testParentPom)},
+				"foo-core/pom.xml":         {Data: []byte(testModulePom)},
+				"foo-cli/pom.xml":          {Data: []byte(`<project><parent><groupId>com.example</groupId><artifactId>foo</artifactId><version>1.0.0</version></parent><artifactId>foo-cli</artifactId><modules><module>plugin</module></modules></project>`)},
+				"foo-cli/plugin/pom.xml":   {Data: []byte(`<project><parent><groupId>com.example</groupId><artifactId>foo-cli</artifactId><version>1.0.0</version></parent><artifactId>foo-cli-plugin</artifactId></project>`)},
+				"foo-legacy/pom.xml":       {Data: []byte(`<project><artifactId>foo-legacy</artifactId></project>`)},
+				"foo-core/src/Main.java":   {Data: []byte("class Main {}")},
+				"foo-cli/plugin/README.md": {Data: []byte("# Plugin")},
+			},
+			want:        []string{"foo-core/pom.xml", "foo-cli/pom.xml", "foo-cli/plugin/pom.xml"},
+			wantReactor: []string{"com.example:foo", "com.example:foo-core", "com.example:foo-cli", "com.example:foo-cli-plugin"},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:    "missing pom.xml",
+			fsys:    fstest.MapFS{},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "missing module",
+			fsys: fstest.MapFS{
+				"pom.xml": {Data: []byte(testParentPom)},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "invalid pom.xml",
+			fsys: fstest.MapFS{
+				"pom.xml": {Data: []byte(`<project><version>1.0.0</project>`)},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &maven{}
+			got, err := u.FindFiles(tt.fsys)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantReactor, u.reactor)
+		})
+	}
+}
+
+func TestMavenUpdater_Update(t *testing.T) {
+	u := &maven{reactor: []string{"com.example:foo", "com.example:foo-core"}}
+
+	tests := []updaterTestCase{
+		{
+			name:    "parent pom",
+			content: testParentPom,
+			info: ReleaseInfo{
+				Version: "v1.0.1",
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.3.0</version>
+  </parent>
+
+  <groupId>com.example</groupId>
+  <artifactId>foo</artifactId>
+  <!-- managed by releaser-pleaser -->
+  <version>1.0.1</version>
+  <packaging>pom</packaging>
+
+  <modules>
+    <module>foo-core</module>
+    <module>foo-cli/pom.xml</module>
+  </modules>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>bar</artifactId>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "module",
+			content: testModulePom,
+			info: ReleaseInfo{
+				Version: "v1.0.1",
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>foo</artifactId>
+    <version>1.0.1</version>
+  </parent>
+
+  <artifactId>foo-core</artifactId>
+</project>
+`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "module with own version",
+			content: "<project>\n  <parent><groupId>com.example</groupId><artifactId>foo</artifactId><version>1.0.0</version></parent>\n  <artifactId>foo-core</artifactId>\n  <version>\n    1.0.0\n  </version>\n</project>\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "<project>\n  <parent><groupId>com.example</groupId><artifactId>foo</artifactId><version>1.1.0</version></parent>\n  <artifactId>foo-core</artifactId>\n  <version>\n    1.1.0\n  </version>\n</project>\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "version from property",
+			content: "<project>\n  <artifactId>foo</artifactId>\n  <version>${revision}</version>\n</project>\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "<project>\n  <artifactId>foo</artifactId>\n  <version>${revision}</version>\n</project>\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid xml",
+			content: "<project>",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, u, tt)
+		})
+	}
+}
+
+func TestMavenUpdater_UpdateAfterRelease(t *testing.T) {
+	u, ok := Maven(true).(PostReleaseUpdater)
+	require.True(t, ok)
+
+	got, err := u.UpdateAfterRelease(ReleaseInfo{Version: "1.0.1"})("<project><version>1.0.1</version></project>")
+	require.NoError(t, err)
+	assert.Equal(t, "<project><version>1.0.2-SNAPSHOT</version></project>", got)
+
+	_, ok = Maven(false).(PostReleaseUpdater)
+	assert.False(t, ok)
+}
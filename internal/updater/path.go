@@ -0,0 +1,296 @@
+package updater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	pathSegmentRegex = regexp.MustCompile(`^([^.\[\]]*)((?:\[\d+\])*)$`)
+	pathIndexRegex   = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+// Path creates an updater that sets the value at valuePath in a JSON, YAML or TOML file to the version. The format is
+// chosen by the file extension. The path is a list of keys separated by dots, with optional array indexes, e.g.
+// `image.tag`, `$.version` or `containers[0].image`. The version is written without the "v", unless it is passed as
+// prefix.
+//
+// The file must contain the path and the value must be a string, or any scalar value in JSON and YAML.
+func Path(file, valuePath, prefix string) Updater {
+	return pathUpdater{
+		file:   file,
+		path:   valuePath,
+		prefix: prefix,
+	}
+}
+
+type pathUpdater struct {
+	file   string
+	path   string
+	prefix string
+}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (p pathUpdater) Files() []string {
+	return []string{p.file}
+}
+
+func (p pathUpdater) CreateNewFiles() bool {
+	return false
+}
+
+func (p pathUpdater) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		value := p.prefix + strings.TrimPrefix(info.Version, "v")
+
+		segments, err := parsePath(p.path)
+		if err != nil {
+			return "", err
+		}
+
+		var updated string
+		var found bool
+		switch ext := strings.ToLower(path.Ext(p.file)); ext {
+		case ".json":
+			updated, found, err = setJSONPath(content, segments, value)
+		case ".yaml", ".yml":
+			updated, found, err = setYAMLPath(content, segments, value)
+		case ".toml":
+			updated, found, err = setTOMLPath(content, segments, value)
+		default:
+			return "", fmt.Errorf("unsupported file type %q of %s, must be .json, .yaml, .yml or .toml", ext, p.file)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to set %s in %s: %w", p.path, p.file, err)
+		}
+		if !found {
+			return "", fmt.Errorf("path %s does not exist in %s", p.path, p.file)
+		}
+
+		return updated, nil
+	}
+}
+
+// parsePath splits a path like `$.spec.containers[0].image` into its keys and indexes.
+func parsePath(valuePath string) ([]pathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(valuePath, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid path %q: path is empty", valuePath)
+	}
+
+	var segments []pathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		match := pathSegmentRegex.FindStringSubmatch(part)
+		if match == nil || (match[1] == "" && match[2] == "") {
+			return nil, fmt.Errorf("invalid path %q: unexpected segment %q", valuePath, part)
+		}
+
+		if match[1] != "" {
+			segments = append(segments, pathSegment{key: match[1]})
+		}
+		for _, index := range pathIndexRegex.FindAllStringSubmatch(match[2], -1) {
+			i, err := strconv.Atoi(index[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", valuePath, err)
+			}
+			segments = append(segments, pathSegment{index: i, isIndex: true})
+		}
+	}
+
+	return segments, nil
+}
+
+// setJSONPath replaces the value at the path. The positions of the value are taken from the decoder, so that the rest
+// of the document is kept as is.
+func setJSONPath(content string, segments []pathSegment, value string) (string, bool, error) {
+	type frame struct {
+		array     bool
+		index     int
+		key       string
+		expectKey bool
+	}
+
+	var stack []*frame
+	matches := func() bool {
+		if len(stack) != len(segments) {
+			return false
+		}
+		for i, f := range stack {
+			if f.array != segments[i].isIndex || (f.array && f.index != segments[i].index) || (!f.array && f.key != segments[i].key) {
+				return false
+			}
+		}
+		return true
+	}
+	beginValue := func() {
+		if len(stack) > 0 && stack[len(stack)-1].array {
+			stack[len(stack)-1].index++
+		}
+	}
+	endValue := func() {
+		if len(stack) > 0 && !stack[len(stack)-1].array {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var offset int64
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return content, false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		start := offset + int64(len(content[offset:])-len(strings.TrimLeft(content[offset:], " \t\r\n:,")))
+		end := decoder.InputOffset()
+		offset = end
+
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				beginValue()
+				if matches() {
+					return "", false, fmt.Errorf("value is not a scalar")
+				}
+				stack = append(stack, &frame{array: delim == '[', index: -1, expectKey: delim == '{'})
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+				endValue()
+			}
+			continue
+		}
+
+		if top := len(stack) - 1; top >= 0 && stack[top].expectKey {
+			stack[top].key, _ = token.(string)
+			stack[top].expectKey = false
+			continue
+		}
+
+		beginValue()
+		if matches() {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return "", false, err
+			}
+			return content[:start] + string(encoded) + content[end:], true, nil
+		}
+		endValue()
+	}
+}
+
+// setYAMLPath replaces the scalar at the path. The position of the scalar is taken from the parsed node, so that the
+// rest of the document including comments is kept as is. The quoting style of the scalar is kept.
+func setYAMLPath(content string, segments []pathSegment, value string) (string, bool, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return "", false, err
+	}
+	if len(document.Content) == 0 {
+		return content, false, nil
+	}
+
+	node := document.Content[0]
+	for _, segment := range segments {
+		node = yamlChild(node, segment)
+		if node == nil {
+			return content, false, nil
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return "", false, fmt.Errorf("value is not a scalar")
+	}
+
+	var replacement string
+	var length int
+	switch node.Style {
+	case 0:
+		replacement, length = value, len([]rune(node.Value))
+	case yaml.DoubleQuotedStyle:
+		replacement, length = strconv.Quote(value), len([]rune(node.Value))+2
+	case yaml.SingleQuotedStyle:
+		replacement, length = "'"+value+"'", len([]rune(node.Value))+2
+	default:
+		return "", false, fmt.Errorf("block scalars are not supported")
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	line := []rune(lines[node.Line-1])
+	column := node.Column - 1
+	if column+length > len(line) {
+		return "", false, fmt.Errorf("multi-line scalars are not supported")
+	}
+
+	lines[node.Line-1] = string(line[:column]) + replacement + string(line[column+length:])
+	return strings.Join(lines, ""), true, nil
+}
+
+func yamlChild(node *yaml.Node, segment pathSegment) *yaml.Node {
+	switch {
+	case node.Kind == yaml.MappingNode && !segment.isIndex:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment.key {
+				return node.Content[i+1]
+			}
+		}
+	case node.Kind == yaml.SequenceNode && segment.isIndex:
+		if segment.index < len(node.Content) {
+			return node.Content[segment.index]
+		}
+	}
+
+	return nil
+}
+
+// setTOMLPath replaces the string at the path. The path may point to a key in a table, an inline table or a dotted
+// key, e.g. `tool.poetry.version` matches `version` in `[tool.poetry]` and `poetry.version` in `[tool]`.
+func setTOMLPath(content string, segments []pathSegment, value string) (string, bool, error) {
+	keys := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment.isIndex {
+			return "", false, fmt.Errorf("array indexes are not supported in TOML")
+		}
+		keys = append(keys, segment.key)
+	}
+
+	setValue := func(string) string { return value }
+
+	tables := splitTOMLTables(content)
+	for _, table := range tables {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if table.name != strings.Join(keys[:i], ".") || table.array {
+				continue
+			}
+
+			if table.setStringValue(strings.Join(keys[i:], "."), setValue) {
+				return joinTOMLTables(tables), true, nil
+			}
+
+			// The last two keys may be an inline table, e.g. `package = { version = "1.0.0" }`
+			if i < len(keys)-1 {
+				if line, ok := table.inlineTables()[strings.Join(keys[i:len(keys)-1], ".")]; ok && table.setInlineStringValue(line, keys[len(keys)-1], setValue) {
+					return joinTOMLTables(tables), true, nil
+				}
+			}
+		}
+	}
+
+	return content, false, nil
+}
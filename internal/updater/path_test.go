@@ -0,0 +1,195 @@
+package updater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathUpdater_Files(t *testing.T) {
+	assert.Equal(t, []string{"deploy/values.yaml"}, Path("deploy/values.yaml", "image.tag", "").Files())
+}
+
+func TestPathUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Path("deploy/values.yaml", "image.tag", "").CreateNewFiles())
+}
+
+func TestPathUpdater_Update(t *testing.T) {
+	tests := []struct {
+		updaterTestCase
+		file   string
+		path   string
+		prefix string
+	}{
+		{
+			file: "manifest.json",
+			path: "$.version",
+			updaterTestCase: updaterTestCase{
+				name:    "json",
+				content: "{\n  \"name\": \"foo\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\n    \"version\": \"1.0.0\"\n  }\n}\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "{\n  \"name\": \"foo\",\n  \"version\": \"1.1.0\",\n  \"dependencies\": {\n    \"version\": \"1.0.0\"\n  }\n}\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "manifest.json",
+			path: "plugins[1].meta.version",
+			updaterTestCase: updaterTestCase{
+				name:    "json nested with index",
+				content: `{"plugins":[{"meta":{"version":"1.0.0"}},{"name":"bar","meta":{"tags":["a","b"],"version":1}}],"version":"0.1.0"}`,
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    `{"plugins":[{"meta":{"version":"1.0.0"}},{"name":"bar","meta":{"tags":["a","b"],"version":"1.1.0"}}],"version":"0.1.0"}`,
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "manifest.json",
+			path: "version",
+			updaterTestCase: updaterTestCase{
+				name:    "json not a scalar",
+				content: `{"version":{"major":1}}`,
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "",
+				wantErr: assert.Error,
+			},
+		},
+		{
+			file:   "deploy/values.yaml",
+			path:   "image.tag",
+			prefix: "v",
+			updaterTestCase: updaterTestCase{
+				name:    "yaml with prefix",
+				content: "# Default values\nimage:\n  repository: ghcr.io/apricote/foo\n  tag: v1.0.0 # the image tag\nreplicas: 1\n",
+				info:    ReleaseInfo{Version: "1.1.0"},
+				want:    "# Default values\nimage:\n  repository: ghcr.io/apricote/foo\n  tag: v1.1.0 # the image tag\nreplicas: 1\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "deploy/deployment.yml",
+			path: "spec.template.spec.containers[1].env[0].value",
+			updaterTestCase: updaterTestCase{
+				name:    "yaml nested with index and quotes",
+				content: "spec:\n  template:\n    spec:\n      containers:\n        - name: proxy\n        - name: foo\n          env:\n            - name: VERSION\n              value: \"1.0.0\"\n",
+				info:    ReleaseInfo{Version: "v1.1.0-rc.1"},
+				want:    "spec:\n  template:\n    spec:\n      containers:\n        - name: proxy\n        - name: foo\n          env:\n            - name: VERSION\n              value: \"1.1.0-rc.1\"\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "values.yaml",
+			path: "image",
+			updaterTestCase: updaterTestCase{
+				name:    "yaml single quotes",
+				content: "image: 'foo'\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "image: '1.1.0'\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "values.yaml",
+			path: "image.tag",
+			updaterTestCase: updaterTestCase{
+				name:    "yaml missing path",
+				content: "image:\n  repository: foo\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "",
+				wantErr: assert.Error,
+			},
+		},
+		{
+			file: "Config.toml",
+			path: "app.version",
+			updaterTestCase: updaterTestCase{
+				name:    "toml table",
+				content: "[app]\nname = \"foo\"\nversion = \"1.0.0\" # managed by releaser-pleaser\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "[app]\nname = \"foo\"\nversion = \"1.1.0\" # managed by releaser-pleaser\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "Config.toml",
+			path: "app.meta.version",
+			updaterTestCase: updaterTestCase{
+				name:    "toml dotted key and inline table",
+				content: "[app]\nmeta.version = \"1.0.0\"\n\n[other]\nmeta = { version = \"1.0.0\" }\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "[app]\nmeta.version = \"1.1.0\"\n\n[other]\nmeta = { version = \"1.0.0\" }\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "Config.toml",
+			path: "other.meta.version",
+			updaterTestCase: updaterTestCase{
+				name:    "toml inline table",
+				content: "[other]\nmeta = { version = \"1.0.0\" }\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "[other]\nmeta = { version = \"1.1.0\" }\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			file: "Config.toml",
+			path: "version",
+			updaterTestCase: updaterTestCase{
+				name:    "toml missing path",
+				content: "[app]\nversion = \"1.0.0\"\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "",
+				wantErr: assert.Error,
+			},
+		},
+		{
+			file: "version.txt",
+			path: "version",
+			updaterTestCase: updaterTestCase{
+				name:    "unsupported file type",
+				content: "1.0.0\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "",
+				wantErr: assert.Error,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, Path(tt.file, tt.path, tt.prefix), tt.updaterTestCase)
+		})
+	}
+}
+
+func Test_parsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []pathSegment
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{path: "version", want: []pathSegment{{key: "version"}}, wantErr: assert.NoError},
+		{path: "$.version", want: []pathSegment{{key: "version"}}, wantErr: assert.NoError},
+		{path: "image.tag", want: []pathSegment{{key: "image"}, {key: "tag"}}, wantErr: assert.NoError},
+		{
+			path:    "$.containers[0][2].image",
+			want:    []pathSegment{{key: "containers"}, {index: 0, isIndex: true}, {index: 2, isIndex: true}, {key: "image"}},
+			wantErr: assert.NoError,
+		},
+		{path: "$[1]", want: []pathSegment{{index: 1, isIndex: true}}, wantErr: assert.NoError},
+		{path: "$", wantErr: assert.Error},
+		{path: "image..tag", wantErr: assert.Error},
+		{path: "image[a]", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := parsePath(tt.path)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const PyprojectFile = "pyproject.toml"
+
+var (
+	// pyprojectDynamicVersionRegex matches `dynamic = ["version"]`, the array may span multiple lines.
+	pyprojectDynamicVersionRegex = regexp.MustCompile(`(?m)^\s*dynamic\s*=\s*\[[^\]]*["']version["']`)
+	pythonVersionVariableRegex   = regexp.MustCompile(`(?m)^(__version__\s*(?::\s*str\s*)?=\s*["'])([^"']*)(["'])`)
+	pythonModuleNameRegex        = regexp.MustCompile(`[-.]+`)
+
+	// pep440PrereleaseTypes maps the pre-release types of releaser-pleaser to the PEP 440 pre-release segments.
+	pep440PrereleaseTypes = map[string]string{
+		"alpha": "a",
+		"beta":  "b",
+		"rc":    "rc",
+	}
+)
+
+// Pyproject creates an updater that modifies the version of Python packages in `project.version` (PEP 621) and
+// `tool.poetry.version` of pyproject.toml. Projects that declare the version as dynamic are left alone. If initVersion
+// is true and the package has an `__init__.py` with a `__version__` variable, it is updated as well. The version is
+// converted to PEP 440.
+func Pyproject(initVersion bool) Updater {
+	return pyproject{
+		initVersion: initVersion,
+	}
+}
+
+type pyproject struct {
+	initVersion bool
+}
+
+type pyprojectManifest struct {
+	Project struct {
+		Name string `toml:"name"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name string `toml:"name"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func (p pyproject) Files() []string {
+	return []string{PyprojectFile}
+}
+
+func (p pyproject) CreateNewFiles() bool {
+	return false
+}
+
+// FindFiles returns the `__init__.py` of the package, in the flat layout (`<package>/__init__.py`) or the src layout
+// (`src/<package>/__init__.py`). No files are returned if initVersion is false.
+func (p pyproject) FindFiles(fsys fs.FS) ([]string, error) {
+	if !p.initVersion {
+		return nil, nil
+	}
+
+	content, err := fs.ReadFile(fsys, PyprojectFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifest pyprojectManifest
+	if err = toml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PyprojectFile, err)
+	}
+
+	name := manifest.Project.Name
+	if name == "" {
+		name = manifest.Tool.Poetry.Name
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	module := strings.ToLower(pythonModuleNameRegex.ReplaceAllString(name, "_"))
+	for _, file := range []string{path.Join(module, "__init__.py"), path.Join("src", module, "__init__.py")} {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		if pythonVersionVariableRegex.Match(content) {
+			return []string{file}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p pyproject) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		version, err := pep440Version(info.Version)
+		if err != nil {
+			return "", err
+		}
+		setVersion := func(string) string { return version }
+
+		// __init__.py
+		if pythonVersionVariableRegex.MatchString(content) {
+			return pythonVersionVariableRegex.ReplaceAllString(content, "${1}"+version+"${3}"), nil
+		}
+
+		tables := splitTOMLTables(content)
+		for _, table := range tables {
+			switch table.name {
+			case "project":
+				if !pyprojectDynamicVersionRegex.MatchString(strings.Join(table.lines, "")) {
+					table.setStringValue("version", setVersion)
+				}
+			case "tool.poetry":
+				table.setStringValue("version", setVersion)
+			}
+		}
+
+		return joinTOMLTables(tables), nil
+	}
+}
+
+// pep440Version converts a version like `v1.2.0-rc.1` to the PEP 440 form `1.2.0rc1`.
+func pep440Version(version string) (string, error) {
+	version = strings.TrimPrefix(version, "v")
+	version, local, hasLocal := strings.Cut(version, "+")
+
+	release, prerelease, isPrerelease := strings.Cut(version, "-")
+	if isPrerelease {
+		preType, preNum, _ := strings.Cut(prerelease, ".")
+		segment, ok := pep440PrereleaseTypes[preType]
+		if !ok {
+			return "", fmt.Errorf("pre-release %q of version %q can not be converted to PEP 440", prerelease, version)
+		}
+		if preNum == "" {
+			preNum = "0"
+		}
+
+		release += segment + preNum
+	}
+
+	if hasLocal {
+		release += "+" + local
+	}
+
+	return release, nil
+}
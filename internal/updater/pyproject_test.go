@@ -0,0 +1,187 @@
+package updater
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPyprojectUpdater_Files(t *testing.T) {
+	assert.Equal(t, []string{"pyproject.toml"}, Pyproject(false).Files())
+}
+
+func TestPyprojectUpdater_CreateNewFiles(t *testing.T) {
+	assert.False(t, Pyproject(false).CreateNewFiles())
+}
+
+func TestPyprojectUpdater_FindFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fstest.MapFS
+		initVersion bool
+		want        []string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "flat layout",
+			fsys: fstest.MapFS{
+				"pyproject.toml":         {Data: []byte("[project]\nname = \"My-Package\"\nversion = \"1.0.0\"\n")},
+				"my_package/__init__.py": {Data: []byte("__version__ = \"1.0.0\"\n")},
+			},
+			initVersion: true,
+			want:        []string{"my_package/__init__.py"},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "init version disabled",
+			fsys: fstest.MapFS{
+				"pyproject.toml":         {Data: []byte("[project]\nname = \"my-package\"\nversion = \"1.0.0\"\n")},
+				"my_package/__init__.py": {Data: []byte("__version__ = \"1.0.0\"\n")},
+			},
+			initVersion: false,
+			want:        nil,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "src layout with poetry",
+			fsys: fstest.MapFS{
+				"pyproject.toml":             {Data: []byte("[tool.poetry]\nname = \"my.package\"\nversion = \"1.0.0\"\n")},
+				"src/my_package/__init__.py": {Data: []byte("__version__: str = '1.0.0'\n")},
+			},
+			initVersion: true,
+			want:        []string{"src/my_package/__init__.py"},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "without __version__",
+			fsys: fstest.MapFS{
+				"pyproject.toml":         {Data: []byte("[project]\nname = \"my-package\"\nversion = \"1.0.0\"\n")},
+				"my_package/__init__.py": {Data: []byte("from .main import main\n")},
+			},
+			initVersion: true,
+			want:        nil,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "missing pyproject.toml",
+			fsys:        fstest.MapFS{},
+			initVersion: true,
+			want:        nil,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "invalid pyproject.toml",
+			fsys: fstest.MapFS{
+				"pyproject.toml": {Data: []byte("[project\n")},
+			},
+			initVersion: true,
+			want:        nil,
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pyproject{initVersion: tt.initVersion}.FindFiles(tt.fsys)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPyprojectUpdater_Update(t *testing.T) {
+	tests := []updaterTestCase{
+		{
+			name:    "project",
+			content: "[build-system]\nrequires = [\"hatchling\"]\n\n[project]\nname = \"foo\"\nversion = \"1.0.0\" # managed by releaser-pleaser\ndependencies = [\n  \"requests>=2.0.0\",\n]\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "[build-system]\nrequires = [\"hatchling\"]\n\n[project]\nname = \"foo\"\nversion = \"1.1.0\" # managed by releaser-pleaser\ndependencies = [\n  \"requests>=2.0.0\",\n]\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "poetry",
+			content: "[tool.poetry]\nname = \"foo\"\nversion = '1.0.0'\n\n[tool.poetry.dependencies]\npython = \"^3.12\"\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "[tool.poetry]\nname = \"foo\"\nversion = '1.1.0'\n\n[tool.poetry.dependencies]\npython = \"^3.12\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "dynamic version",
+			content: "[project]\nname = \"foo\"\ndynamic = [\n  \"version\",\n]\n\n[tool.hatch.version]\npath = \"foo/__init__.py\"\n",
+			info: ReleaseInfo{
+				Version: "v1.1.0",
+			},
+			want:    "[project]\nname = \"foo\"\ndynamic = [\n  \"version\",\n]\n\n[tool.hatch.version]\npath = \"foo/__init__.py\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "prerelease",
+			content: "[project]\nname = \"foo\"\nversion = \"1.0.0\"\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0-rc.1",
+			},
+			want:    "[project]\nname = \"foo\"\nversion = \"1.2.0rc1\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "__init__.py",
+			content: "\"\"\"The foo package.\"\"\"\n\n__version__ = \"1.0.0\"\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0-beta.2",
+			},
+			want:    "\"\"\"The foo package.\"\"\"\n\n__version__ = \"1.2.0b2\"\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "unknown prerelease",
+			content: "[project]\nname = \"foo\"\nversion = \"1.0.0\"\n",
+			info: ReleaseInfo{
+				Version: "v1.2.0-preview.1",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUpdaterTest(t, Pyproject(true), tt)
+		})
+	}
+}
+
+func Test_pep440Version(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{version: "1.2.0", want: "1.2.0", wantErr: assert.NoError},
+		{version: "v1.2.0", want: "1.2.0", wantErr: assert.NoError},
+		{version: "v1.2.0-alpha.0", want: "1.2.0a0", wantErr: assert.NoError},
+		{version: "v1.2.0-beta.3", want: "1.2.0b3", wantErr: assert.NoError},
+		{version: "v1.2.0-rc.1", want: "1.2.0rc1", wantErr: assert.NoError},
+		{version: "v1.2.0-rc", want: "1.2.0rc0", wantErr: assert.NoError},
+		{version: "2024.01.0-rc.1", want: "2024.01.0rc1", wantErr: assert.NoError},
+		{version: "v1.2.0-rc.1+build.5", want: "1.2.0rc1+build.5", wantErr: assert.NoError},
+		{version: "v1.2.0-dev.1", want: "", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := pep440Version(tt.version)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package updater
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const (
+	// RegexVersionGroup is the name of the capture group that is replaced by the regex updater.
+	RegexVersionGroup = "version"
+	// DefaultRegexTemplate is used by the regex updater if no template is configured.
+	DefaultRegexTemplate = "{{.Version}}"
+)
+
+// Regex creates an updater that replaces the capture group named RegexVersionGroup in all matches of the pattern. The
+// replacement is rendered from the Go template tpl with VersionTemplateData, e.g. `{{.Major}}` for `ARG
+// VERSION_MAJOR=(?P<version>\d+)`.
+func Regex(files []string, pattern, tpl string) (Updater, error) {
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regex %q: %w", pattern, err)
+	}
+	if expression.SubexpIndex(RegexVersionGroup) < 0 {
+		return nil, fmt.Errorf("regex %q must contain a capture group named %q, e.g. (?P<%s>.+)", pattern, RegexVersionGroup, RegexVersionGroup)
+	}
+
+	if tpl == "" {
+		tpl = DefaultRegexTemplate
+	}
+	replacement, err := template.New("regex").Option("missingkey=error").Parse(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regex template %q: %w", tpl, err)
+	}
+
+	return regex{
+		files:       files,
+		expression:  expression,
+		replacement: replacement,
+	}, nil
+}
+
+type regex struct {
+	files       []string
+	expression  *regexp.Regexp
+	replacement *template.Template
+}
+
+func (r regex) Files() []string {
+	return r.files
+}
+
+func (r regex) CreateNewFiles() bool {
+	return false
+}
+
+func (r regex) Update(info ReleaseInfo) func(content string) (string, error) {
+	return func(content string) (string, error) {
+		var value strings.Builder
+		if err := r.replacement.Execute(&value, NewVersionTemplateData(info.Version)); err != nil {
+			return "", fmt.Errorf("failed to render regex template: %w", err)
+		}
+
+		group := r.expression.SubexpIndex(RegexVersionGroup)
+
+		var updated strings.Builder
+		last := 0
+		for _, match := range r.expression.FindAllStringSubmatchIndex(content, -1) {
+			start, end := match[2*group], match[2*group+1]
+			if start < 0 {
+				// The group is optional and did not participate in the match
+				continue
+			}
+
+			updated.WriteString(content[last:start])
+			updated.WriteString(value.String())
+			last = end
+		}
+		updated.WriteString(content[last:])
+
+		return updated.String(), nil
+	}
+}
@@ -0,0 +1,136 @@
+package updater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexUpdater_Files(t *testing.T) {
+	u, err := Regex([]string{"Dockerfile"}, `VERSION=(?P<version>.+)`, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dockerfile"}, u.Files())
+}
+
+func TestRegexUpdater_CreateNewFiles(t *testing.T) {
+	u, err := Regex([]string{"Dockerfile"}, `VERSION=(?P<version>.+)`, "")
+	require.NoError(t, err)
+	assert.False(t, u.CreateNewFiles())
+}
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		template string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{name: "valid", pattern: `v(?P<version>\d+)`, template: "{{.Major}}", wantErr: assert.NoError},
+		{name: "default template", pattern: `v(?P<version>\d+)`, template: "", wantErr: assert.NoError},
+		{name: "invalid regex", pattern: `v(?P<version>\d+`, template: "", wantErr: assert.Error},
+		{name: "missing group", pattern: `v(\d+)`, template: "", wantErr: assert.Error},
+		{name: "invalid template", pattern: `v(?P<version>\d+)`, template: "{{.Major", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Regex(nil, tt.pattern, tt.template)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestRegexUpdater_Update(t *testing.T) {
+	tests := []struct {
+		updaterTestCase
+		pattern  string
+		template string
+	}{
+		{
+			pattern:  `ARG VERSION_MAJOR=(?P<version>\d+)`,
+			template: "{{.Major}}",
+			updaterTestCase: updaterTestCase{
+				name:    "major version",
+				content: "FROM alpine\nARG VERSION_MAJOR=1\nARG VERSION_MINOR=4\n",
+				info:    ReleaseInfo{Version: "v2.0.0"},
+				want:    "FROM alpine\nARG VERSION_MAJOR=2\nARG VERSION_MINOR=4\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `https://example\.com/docs/(?P<version>v\d+)/`,
+			template: "v{{.Major}}",
+			updaterTestCase: updaterTestCase{
+				name:    "all matches",
+				content: "See https://example.com/docs/v1/install and https://example.com/docs/v1/usage.\n",
+				info:    ReleaseInfo{Version: "v2.1.0"},
+				want:    "See https://example.com/docs/v2/install and https://example.com/docs/v2/usage.\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `FOO_VERSION="(?P<version>[^"]+)"`,
+			template: "{{.Major}}.{{.Minor}}",
+			updaterTestCase: updaterTestCase{
+				name:    "two-part version",
+				content: "#!/bin/sh\nFOO_VERSION=\"1.3\"\n",
+				info:    ReleaseInfo{Version: "v1.4.2"},
+				want:    "#!/bin/sh\nFOO_VERSION=\"1.4\"\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `image: foo:(?P<version>\S+)`,
+			template: "",
+			updaterTestCase: updaterTestCase{
+				name:    "default template",
+				content: "image: foo:1.0.0\n",
+				info:    ReleaseInfo{Version: "v1.1.0-rc.1"},
+				want:    "image: foo:1.1.0-rc.1\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `channel: (?P<version>\S+)`,
+			template: `{{if .Prerelease}}{{.Prerelease}}{{else}}stable{{end}}`,
+			updaterTestCase: updaterTestCase{
+				name:    "prerelease",
+				content: "channel: stable\n",
+				info:    ReleaseInfo{Version: "v1.1.0-rc.1"},
+				want:    "channel: rc.1\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `VERSION=(?P<version>\S+)( # (?P<comment>.+))?`,
+			template: "{{.Patch}}",
+			updaterTestCase: updaterTestCase{
+				name:    "no match",
+				content: "NAME=foo\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "NAME=foo\n",
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			pattern:  `VERSION=(?P<version>\S+)`,
+			template: "{{.Foo}}",
+			updaterTestCase: updaterTestCase{
+				name:    "unknown field",
+				content: "VERSION=1.0.0\n",
+				info:    ReleaseInfo{Version: "v1.1.0"},
+				want:    "",
+				wantErr: assert.Error,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := Regex([]string{"file"}, tt.pattern, tt.template)
+			require.NoError(t, err)
+
+			runUpdaterTest(t, u, tt.updaterTestCase)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package updater
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SnapshotSuffix marks the development versions of JVM projects.
+const SnapshotSuffix = "-SNAPSHOT"
+
+type findingUpdater interface {
+	Updater
+	FileFinder
+}
+
+// snapshot wraps an updater, so that it sets the next snapshot version after the release.
+type snapshot struct {
+	findingUpdater
+}
+
+func (s snapshot) UpdateAfterRelease(info ReleaseInfo) func(content string) (string, error) {
+	return s.Update(ReleaseInfo{Version: NextSnapshotVersion(info.Version)})
+}
+
+// NextSnapshotVersion returns the development version that follows the release, e.g. `1.2.4-SNAPSHOT` after `1.2.3`.
+// Pre-releases are followed by the snapshot of the stable version, e.g. `1.3.0-SNAPSHOT` after `1.3.0-rc.1`.
+func NextSnapshotVersion(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "+")
+
+	version, _, isPrerelease := strings.Cut(version, "-")
+	if isPrerelease {
+		return version + SnapshotSuffix
+	}
+
+	// Increment the last segment, this works for SemVer and CalVer formats that end with a counter. Padded segments
+	// like `0M` keep their width.
+	prefix, last := "", version
+	if i := strings.LastIndex(version, "."); i >= 0 {
+		prefix, last = version[:i+1], version[i+1:]
+	}
+	if counter, err := strconv.Atoi(last); err == nil {
+		version = prefix + fmt.Sprintf("%0*d", len(last), counter+1)
+	}
+
+	return version + SnapshotSuffix
+}
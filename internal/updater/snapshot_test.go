@@ -0,0 +1,28 @@
+package updater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextSnapshotVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.2.3", want: "1.2.4-SNAPSHOT"},
+		{version: "v1.2.3", want: "1.2.4-SNAPSHOT"},
+		{version: "1.2.9", want: "1.2.10-SNAPSHOT"},
+		{version: "1.3.0-rc.1", want: "1.3.0-SNAPSHOT"},
+		{version: "1.2.3+build.5", want: "1.2.4-SNAPSHOT"},
+		{version: "2024.01.0", want: "2024.01.1-SNAPSHOT"},
+		{version: "2024.01", want: "2024.02-SNAPSHOT"},
+		{version: "1", want: "2-SNAPSHOT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextSnapshotVersion(tt.version))
+		})
+	}
+}
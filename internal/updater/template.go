@@ -0,0 +1,34 @@
+package updater
+
+import "strings"
+
+// VersionTemplateData is passed to the templates of the regex updater and the blocks of the generic updater.
+type VersionTemplateData struct {
+	// Version is the full version without the "v" prefix, e.g. `1.2.3-rc.1`.
+	Version string
+	// Major, Minor and Patch are the first three segments of the version, e.g. `1`, `2` and `3`. They are empty if the
+	// version has less segments.
+	Major string
+	Minor string
+	Patch string
+	// Prerelease is the part after the "-", e.g. `rc.1`. It is empty for stable versions.
+	Prerelease string
+}
+
+// NewVersionTemplateData splits the version into its segments.
+func NewVersionTemplateData(version string) VersionTemplateData {
+	version = strings.TrimPrefix(version, "v")
+	data := VersionTemplateData{Version: version}
+
+	version, _, _ = strings.Cut(version, "+")
+	version, data.Prerelease, _ = strings.Cut(version, "-")
+
+	segments := strings.Split(version, ".")
+	for i, segment := range []*string{&data.Major, &data.Minor, &data.Patch} {
+		if i < len(segments) {
+			*segment = segments[i]
+		}
+	}
+
+	return data
+}
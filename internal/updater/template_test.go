@@ -0,0 +1,25 @@
+package updater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionTemplateData(t *testing.T) {
+	tests := []struct {
+		version string
+		want    VersionTemplateData
+	}{
+		{version: "v1.2.3", want: VersionTemplateData{Version: "1.2.3", Major: "1", Minor: "2", Patch: "3"}},
+		{version: "1.2.3-rc.1", want: VersionTemplateData{Version: "1.2.3-rc.1", Major: "1", Minor: "2", Patch: "3", Prerelease: "rc.1"}},
+		{version: "1.2.3+build.1", want: VersionTemplateData{Version: "1.2.3+build.1", Major: "1", Minor: "2", Patch: "3"}},
+		{version: "2024.01.0", want: VersionTemplateData{Version: "2024.01.0", Major: "2024", Minor: "01", Patch: "0"}},
+		{version: "24.1", want: VersionTemplateData{Version: "24.1", Major: "24", Minor: "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewVersionTemplateData(tt.version))
+		})
+	}
+}
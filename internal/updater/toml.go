@@ -0,0 +1,148 @@
+package updater
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	tomlTableRegex     = regexp.MustCompile(`^\s*(\[\[?)\s*([^\[\]]+?)\s*\]\]?\s*(?:#.*)?$`)
+	tomlTableDotRegex  = regexp.MustCompile(`\s*\.\s*`)
+	tomlInlineKeyRegex = regexp.MustCompile(`^\s*([\w-]+|"[^"]*"|'[^']*')\s*=\s*\{`)
+)
+
+// tomlTable is a table of a TOML document, together with the lines of its key/value pairs. The TOML is only parsed as
+// far as necessary to replace string values line by line, so that comments and formatting are preserved.
+type tomlTable struct {
+	// name is the dotted name of the table, e.g. `workspace.package`. The keys before the first table have the name "".
+	name string
+	// array is true for the tables of an array of tables, e.g. `[[package]]`.
+	array bool
+	// lines of the table including the header and the line endings.
+	lines []string
+}
+
+func splitTOMLTables(content string) []*tomlTable {
+	tables := []*tomlTable{{}}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if match := tomlTableRegex.FindStringSubmatch(strings.TrimRight(line, "\r\n")); match != nil {
+			tables = append(tables, &tomlTable{
+				name:  tomlTableDotRegex.ReplaceAllString(match[2], "."),
+				array: match[1] == "[[",
+			})
+		}
+
+		table := tables[len(tables)-1]
+		table.lines = append(table.lines, line)
+	}
+
+	return tables
+}
+
+func joinTOMLTables(tables []*tomlTable) string {
+	var content strings.Builder
+	for _, table := range tables {
+		for _, line := range table.lines {
+			content.WriteString(line)
+		}
+	}
+
+	return content.String()
+}
+
+// tomlStringValueRegex matches a key with a string value at the start of the line. The value is the third group.
+func tomlStringValueRegex(key string) *regexp.Regexp {
+	return regexp.MustCompile(`(^\s*)` + regexp.QuoteMeta(key) + `(\s*=\s*["'])([^"']*)(["'])`)
+}
+
+// tomlInlineStringValueRegex matches a key with a string value inside an inline table. The value is the third group.
+func tomlInlineStringValueRegex(key string) *regexp.Regexp {
+	return regexp.MustCompile(`([{,]\s*)` + regexp.QuoteMeta(key) + `(\s*=\s*["'])([^"']*)(["'])`)
+}
+
+// stringValue returns the string value of key in the table.
+func (t *tomlTable) stringValue(key string) (string, bool) {
+	keyRegex := tomlStringValueRegex(key)
+	for _, line := range t.lines {
+		if match := keyRegex.FindStringSubmatch(line); match != nil {
+			return match[3], true
+		}
+	}
+
+	return "", false
+}
+
+// hasKey checks if the table sets key to any value.
+func (t *tomlTable) hasKey(key string) bool {
+	keyRegex := regexp.MustCompile(`^\s*` + regexp.QuoteMeta(key) + `\s*=`)
+	for _, line := range t.lines {
+		if keyRegex.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setStringValue replaces the string value of key in the table with the result of update. It returns false if the
+// table does not contain key with a string value.
+func (t *tomlTable) setStringValue(key string, update func(string) string) bool {
+	keyRegex := tomlStringValueRegex(key)
+	for i, line := range t.lines {
+		if keyRegex.MatchString(line) {
+			t.lines[i] = setTOMLStringValue(keyRegex, line, update)
+			return true
+		}
+	}
+
+	return false
+}
+
+func setTOMLStringValue(keyRegex *regexp.Regexp, line string, update func(string) string) string {
+	match := keyRegex.FindStringSubmatchIndex(line)
+	return line[:match[6]] + update(line[match[6]:match[7]]) + line[match[7]:]
+}
+
+// inlineTables returns the keys of the inline tables in the table, e.g. `foo` for `foo = { path = "../foo" }`, and
+// the line they are on.
+func (t *tomlTable) inlineTables() map[string]int {
+	tables := make(map[string]int)
+	for i, line := range t.lines {
+		if match := tomlInlineKeyRegex.FindStringSubmatch(line); match != nil {
+			tables[strings.Trim(match[1], `"'`)] = i
+		}
+	}
+
+	return tables
+}
+
+// inlineStringValue returns the string value of key in the inline table on line i.
+func (t *tomlTable) inlineStringValue(i int, key string) (string, bool) {
+	match := tomlInlineStringValueRegex(key).FindStringSubmatch(t.lines[i])
+	if match == nil {
+		return "", false
+	}
+
+	return match[3], true
+}
+
+// setInlineStringValue replaces the string value of key in the inline table on line i with the result of update.
+func (t *tomlTable) setInlineStringValue(i int, key string, update func(string) string) bool {
+	keyRegex := tomlInlineStringValueRegex(key)
+	if !keyRegex.MatchString(t.lines[i]) {
+		return false
+	}
+
+	t.lines[i] = setTOMLStringValue(keyRegex, t.lines[i], update)
+	return true
+}
+
+// inlineHasKey checks if the inline table on line i sets key to any value.
+func (t *tomlTable) inlineHasKey(i int, key string) bool {
+	return regexp.MustCompile(`[{,]\s*` + regexp.QuoteMeta(key) + `\s*=`).MatchString(t.lines[i])
+}
@@ -1,5 +1,7 @@
 package updater
 
+import "io/fs"
+
 type ReleaseInfo struct {
 	Version        string
 	ChangelogEntry string
@@ -11,6 +13,20 @@ type Updater interface {
 	Update(info ReleaseInfo) func(content string) (string, error)
 }
 
+// FileFinder is implemented by updaters that need to look at the repository to know which files they update, e.g. the
+// members of a workspace. FindFiles is called before Update with the directory of the component, the returned files
+// are updated in addition to Files.
+type FileFinder interface {
+	FindFiles(fsys fs.FS) ([]string, error)
+}
+
+// PostReleaseUpdater is implemented by updaters that change the files again after the release was created, e.g. to set
+// the next development version. The info contains the released version, the changes are committed to the target
+// branch.
+type PostReleaseUpdater interface {
+	UpdateAfterRelease(info ReleaseInfo) func(content string) (string, error)
+}
+
 type NewUpdater func(ReleaseInfo) Updater
 
 func WithInfo(info ReleaseInfo, constructors ...NewUpdater) []Updater {
@@ -0,0 +1,351 @@
+package versioning
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apricote/releaser-pleaser/internal/git"
+)
+
+// DefaultCalVerFormat is used when no format is passed to NewCalVer.
+const DefaultCalVerFormat = "YYYY.0M.MICRO"
+
+// calVerSegment is one dot-separated part of a CalVer format, see https://calver.org/#scheme.
+type calVerSegment string
+
+const (
+	segmentFullYear    calVerSegment = "YYYY"
+	segmentShortYear   calVerSegment = "YY"
+	segmentPaddedYear  calVerSegment = "0Y"
+	segmentMonth       calVerSegment = "MM"
+	segmentPaddedMonth calVerSegment = "0M"
+	segmentWeek        calVerSegment = "WW"
+	segmentPaddedWeek  calVerSegment = "0W"
+	segmentDay         calVerSegment = "DD"
+	segmentPaddedDay   calVerSegment = "0D"
+	segmentMicro       calVerSegment = "MICRO"
+	segmentPatch       calVerSegment = "PATCH"
+)
+
+func (s calVerSegment) isCounter() bool {
+	return s == segmentMicro || s == segmentPatch
+}
+
+func (s calVerSegment) isWeek() bool {
+	return s == segmentWeek || s == segmentPaddedWeek
+}
+
+// value returns the value of the segment for a release at date. Years are ISO week-numbering years if the format
+// contains a week, so that the last days of December can be in week 1 of the next year.
+func (s calVerSegment) value(date time.Time, isoWeeks bool) int {
+	year := date.Year()
+	isoYear, week := date.ISOWeek()
+	if isoWeeks {
+		year = isoYear
+	}
+
+	switch s {
+	case segmentFullYear:
+		return year
+	case segmentShortYear, segmentPaddedYear:
+		return year - 2000
+	case segmentMonth, segmentPaddedMonth:
+		return int(date.Month())
+	case segmentWeek, segmentPaddedWeek:
+		return week
+	case segmentDay, segmentPaddedDay:
+		return date.Day()
+	default:
+		return 0
+	}
+}
+
+// valid checks if the value is plausible for the segment, so that tags of other strategies are not mistaken as
+// calendar versions.
+func (s calVerSegment) valid(value int) bool {
+	switch s {
+	case segmentFullYear:
+		return value >= 1000
+	case segmentMonth, segmentPaddedMonth:
+		return value >= 1 && value <= 12
+	case segmentWeek, segmentPaddedWeek:
+		return value >= 1 && value <= 53
+	case segmentDay, segmentPaddedDay:
+		return value >= 1 && value <= 31
+	default:
+		return true
+	}
+}
+
+func (s calVerSegment) format(value int) string {
+	switch s {
+	case segmentPaddedYear, segmentPaddedMonth, segmentPaddedWeek, segmentPaddedDay:
+		return fmt.Sprintf("%02d", value)
+	default:
+		return strconv.Itoa(value)
+	}
+}
+
+type calVer struct {
+	format   string
+	segments []calVerSegment
+	isoWeeks bool
+
+	// now returns the release date, it is replaced in tests.
+	now func() time.Time
+}
+
+// NewCalVer returns a Strategy for calendar versions. The format is a dot-separated list of the segments YYYY, YY, 0Y,
+// MM, 0M, WW, 0W, DD, 0D and MICRO (or PATCH). The date segments are taken from the release date in UTC, the MICRO
+// counter starts at 0 and is incremented for every release in the same period.
+//
+// The version bump of the commits is ignored, except that a release is only created if there are releasable commits.
+// Pre-releases are marked with a suffix like `2024.01.0-rc.1`.
+func NewCalVer(format string) (Strategy, error) {
+	if format == "" {
+		format = DefaultCalVerFormat
+	}
+
+	c := &calVer{format: format, now: time.Now}
+
+	hasDate, hasCounter := false, false
+	for _, part := range strings.Split(format, ".") {
+		segment := calVerSegment(part)
+		switch segment {
+		case segmentFullYear, segmentShortYear, segmentPaddedYear,
+			segmentMonth, segmentPaddedMonth,
+			segmentWeek, segmentPaddedWeek,
+			segmentDay, segmentPaddedDay:
+			hasDate = true
+			c.isoWeeks = c.isoWeeks || segment.isWeek()
+		case segmentMicro, segmentPatch:
+			if hasCounter {
+				return nil, fmt.Errorf("invalid calver format %q: only one MICRO segment is allowed", format)
+			}
+			hasCounter = true
+		default:
+			return nil, fmt.Errorf("invalid calver format %q: unknown segment %q", format, part)
+		}
+
+		c.segments = append(c.segments, segment)
+	}
+
+	if !hasDate {
+		return nil, fmt.Errorf("invalid calver format %q: at least one date segment is required", format)
+	}
+
+	return c, nil
+}
+
+type calVerVersion struct {
+	values []int
+
+	preType string
+	preNum  int
+}
+
+func (v calVerVersion) isPrerelease() bool {
+	return v.preType != ""
+}
+
+func (c *calVer) parse(version string) (calVerVersion, error) {
+	main, pre, hasPre := strings.Cut(version, "-")
+
+	parts := strings.Split(main, ".")
+	if len(parts) != len(c.segments) {
+		return calVerVersion{}, fmt.Errorf("version %q does not match format %q", version, c.format)
+	}
+
+	var parsed calVerVersion
+	for i, part := range parts {
+		value, err := parseNumber(part)
+		if err != nil {
+			return calVerVersion{}, fmt.Errorf("version %q does not match format %q: %w", version, c.format, err)
+		}
+		if !c.segments[i].valid(value) {
+			return calVerVersion{}, fmt.Errorf("version %q does not match format %q: invalid %s %d", version, c.format, c.segments[i], value)
+		}
+		parsed.values = append(parsed.values, value)
+	}
+
+	if hasPre {
+		preType, preNum, ok := strings.Cut(pre, ".")
+		if !ok || preType == "" {
+			return calVerVersion{}, fmt.Errorf("invalid pre-release %q in version %q", pre, version)
+		}
+
+		var err error
+		parsed.preType = preType
+		parsed.preNum, err = parseNumber(preNum)
+		if err != nil {
+			return calVerVersion{}, fmt.Errorf("invalid pre-release %q in version %q: %w", pre, version, err)
+		}
+	}
+
+	return parsed, nil
+}
+
+func parseNumber(s string) (int, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+
+	return strconv.Atoi(s)
+}
+
+func (c *calVer) render(v calVerVersion) string {
+	parts := make([]string, 0, len(v.values))
+	for i, segment := range c.segments {
+		parts = append(parts, segment.format(v.values[i]))
+	}
+
+	version := strings.Join(parts, ".")
+	if v.isPrerelease() {
+		version += fmt.Sprintf("-%s.%d", v.preType, v.preNum)
+	}
+
+	return version
+}
+
+// samePeriod checks if both versions have the same values in all date segments.
+func (c *calVer) samePeriod(a, b calVerVersion) bool {
+	for i, segment := range c.segments {
+		if !segment.isCounter() && a.values[i] != b.values[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Bump returns the bump unchanged, it only decides whether there is a release.
+func (c *calVer) Bump(_ git.Releases, versionBump VersionBump) (VersionBump, error) {
+	return versionBump, nil
+}
+
+func (c *calVer) NextVersion(r git.Releases, versionBump VersionBump, nextVersionType NextVersionType) (string, error) {
+	if versionBump == UnknownVersion {
+		return "", fmt.Errorf("invalid latest bump (unknown)")
+	}
+
+	preType := ""
+	if nextVersionType.IsPrerelease() {
+		preType = nextVersionType.String()
+	}
+
+	return c.next(r, preType)
+}
+
+// Outdated checks if the date segments of the version are from an earlier period than the current date.
+func (c *calVer) Outdated(version string) bool {
+	parsed, err := c.parse(version)
+	if err != nil {
+		return false
+	}
+
+	return !c.samePeriod(parsed, c.today())
+}
+
+// Redate returns the version for a release at the current date. Pre-releases keep their type.
+func (c *calVer) Redate(r git.Releases, version string) (string, error) {
+	parsed, err := c.parse(version)
+	if err != nil {
+		return "", err
+	}
+
+	return c.next(r, parsed.preType)
+}
+
+// today returns a version with the date segments of the current date and all counters set to 0.
+func (c *calVer) today() calVerVersion {
+	date := c.now().UTC()
+
+	version := calVerVersion{values: make([]int, len(c.segments))}
+	for i, segment := range c.segments {
+		version.values[i] = segment.value(date, c.isoWeeks)
+	}
+
+	return version
+}
+
+func (c *calVer) next(r git.Releases, preType string) (string, error) {
+	next := c.today()
+
+	if r.Stable != nil {
+		stable, err := c.parse(r.Stable.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse stable version: %w", err)
+		}
+
+		if c.samePeriod(next, stable) {
+			counter := slices.IndexFunc(c.segments, calVerSegment.isCounter)
+			if counter < 0 {
+				return "", fmt.Errorf("version %s was already released in this period, add a MICRO segment to the format %q", r.Stable.Name, c.format)
+			}
+
+			next.values[counter] = stable.values[counter] + 1
+		}
+	}
+
+	if preType != "" {
+		next.preType = preType
+
+		if r.Latest != nil {
+			latest, err := c.parse(r.Latest.Name)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse latest version: %w", err)
+			}
+
+			if slices.Equal(latest.values, next.values) && latest.preType == next.preType {
+				next.preNum = latest.preNum + 1
+			}
+		}
+	}
+
+	return c.render(next), nil
+}
+
+func (c *calVer) IsPrerelease(version string) bool {
+	parsed, err := c.parse(version)
+	if err != nil {
+		return false
+	}
+
+	return parsed.isPrerelease()
+}
+
+func (c *calVer) IsValid(version string) bool {
+	_, err := c.parse(version)
+	return err == nil
+}
+
+func (c *calVer) Compare(a, b string) int {
+	versionA, errA := c.parse(a)
+	versionB, errB := c.parse(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	if result := slices.Compare(versionA.values, versionB.values); result != 0 {
+		return result
+	}
+
+	// A stable release is higher than its pre-releases
+	switch {
+	case !versionA.isPrerelease() && !versionB.isPrerelease():
+		return 0
+	case !versionA.isPrerelease():
+		return 1
+	case !versionB.isPrerelease():
+		return -1
+	}
+
+	return cmp.Or(
+		strings.Compare(versionA.preType, versionB.preType),
+		cmp.Compare(versionA.preNum, versionB.preNum),
+	)
+}
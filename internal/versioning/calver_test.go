@@ -0,0 +1,302 @@
+package versioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/git"
+)
+
+func newTestCalVer(t *testing.T, format string, now time.Time) Strategy {
+	t.Helper()
+
+	strategy, err := NewCalVer(format)
+	require.NoError(t, err)
+	strategy.(*calVer).now = func() time.Time { return now }
+
+	return strategy
+}
+
+func TestNewCalVer(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "default", format: "", wantErr: assert.NoError},
+		{name: "year month patch", format: "YYYY.MM.PATCH", wantErr: assert.NoError},
+		{name: "short year padded month micro", format: "YY.0M.MICRO", wantErr: assert.NoError},
+		{name: "year padded week", format: "YYYY.0W", wantErr: assert.NoError},
+		{name: "unknown segment", format: "YYYY.MAJOR", wantErr: assert.Error},
+		{name: "no date segment", format: "MICRO", wantErr: assert.Error},
+		{name: "duplicate counter", format: "YYYY.MICRO.PATCH", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCalVer(tt.format)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCalVer_NextVersion(t *testing.T) {
+	october := time.Date(2024, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	type args struct {
+		releases        git.Releases
+		versionBump     VersionBump
+		nextVersionType NextVersionType
+	}
+	tests := []struct {
+		name    string
+		format  string
+		now     time.Time
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "first release",
+			format:  "YYYY.MM.PATCH",
+			now:     october,
+			args:    args{versionBump: PatchVersion},
+			want:    "2024.10.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "same period increments counter",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.10.3"},
+					Stable: &git.Tag{Name: "2024.10.3"},
+				},
+				versionBump: MajorVersion,
+			},
+			want:    "2024.10.4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "new period resets counter",
+			format: "YY.0M.MICRO",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "24.09.5"},
+					Stable: &git.Tag{Name: "24.09.5"},
+				},
+				versionBump: MinorVersion,
+			},
+			want:    "24.10.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "padded month",
+			format: "YY.0M.MICRO",
+			now:    time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			args: args{
+				versionBump: PatchVersion,
+			},
+			want:    "25.03.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "iso week",
+			format: "YYYY.0W",
+			// Monday, 30th December 2024 is in week 1 of 2025
+			now: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+			args: args{
+				versionBump: PatchVersion,
+			},
+			want:    "2025.01",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "same period without counter",
+			format: "YYYY.0W",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.42"},
+					Stable: &git.Tag{Name: "2024.42"},
+				},
+				versionBump: PatchVersion,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:   "first pre-release",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.10.0"},
+					Stable: &git.Tag{Name: "2024.10.0"},
+				},
+				versionBump:     PatchVersion,
+				nextVersionType: NextVersionTypeRC,
+			},
+			want:    "2024.10.1-rc.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "next pre-release",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.10.1-rc.0"},
+					Stable: &git.Tag{Name: "2024.10.0"},
+				},
+				versionBump:     PatchVersion,
+				nextVersionType: NextVersionTypeRC,
+			},
+			want:    "2024.10.1-rc.1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "stable after pre-release",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.10.1-rc.1"},
+					Stable: &git.Tag{Name: "2024.10.0"},
+				},
+				versionBump:     PatchVersion,
+				nextVersionType: NextVersionTypeNormal,
+			},
+			want:    "2024.10.1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "pre-release in new period",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Latest: &git.Tag{Name: "2024.09.1-beta.3"},
+					Stable: &git.Tag{Name: "2024.09.0"},
+				},
+				versionBump:     PatchVersion,
+				nextVersionType: NextVersionTypeBeta,
+			},
+			want:    "2024.10.0-beta.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "unknown bump",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				versionBump: UnknownVersion,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:   "invalid stable tag",
+			format: "YYYY.MM.PATCH",
+			now:    october,
+			args: args{
+				releases: git.Releases{
+					Stable: &git.Tag{Name: "v1.0.0"},
+				},
+				versionBump: PatchVersion,
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := newTestCalVer(t, tt.format, tt.now)
+
+			got, err := strategy.NextVersion(tt.args.releases, tt.args.versionBump, tt.args.nextVersionType)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalVer_IsPrerelease(t *testing.T) {
+	strategy := newTestCalVer(t, "YYYY.0M.MICRO", time.Now())
+
+	assert.False(t, strategy.IsPrerelease("2024.10.0"))
+	assert.True(t, strategy.IsPrerelease("2024.10.0-rc.1"))
+	assert.False(t, strategy.IsPrerelease("v1.0.0-rc.1"))
+}
+
+func TestCalVer_IsValid(t *testing.T) {
+	strategy := newTestCalVer(t, "YYYY.0M.MICRO", time.Now())
+
+	assert.True(t, strategy.IsValid("2024.10.0"))
+	assert.True(t, strategy.IsValid("2024.10.0-rc.1"))
+	assert.False(t, strategy.IsValid("1.2.3"))
+	assert.False(t, strategy.IsValid("v2024.10.0"))
+	assert.False(t, strategy.IsValid("2024.13.0"))
+	assert.False(t, strategy.IsValid("2024.10"))
+	assert.False(t, strategy.IsValid("2024.10.0-rc"))
+}
+
+func TestCalVer_Compare(t *testing.T) {
+	strategy := newTestCalVer(t, "YYYY.0M.MICRO", time.Now())
+
+	assert.Negative(t, strategy.Compare("2024.09.10", "2024.10.0"))
+	assert.Negative(t, strategy.Compare("2024.10.2", "2024.10.10"))
+	assert.Positive(t, strategy.Compare("2024.10.0", "2024.10.0-rc.3"))
+	assert.Negative(t, strategy.Compare("2024.10.0-rc.1", "2024.10.0-rc.2"))
+	assert.Zero(t, strategy.Compare("2024.10.0", "2024.10.0"))
+}
+
+func TestCalVer_Outdated(t *testing.T) {
+	strategy := newTestCalVer(t, "YYYY.0M.MICRO", time.Date(2024, time.April, 2, 12, 0, 0, 0, time.UTC)).(DateStrategy)
+
+	assert.False(t, strategy.Outdated("2024.04.0"))
+	assert.False(t, strategy.Outdated("2024.04.3-rc.1"))
+	assert.True(t, strategy.Outdated("2024.03.1"))
+	assert.True(t, strategy.Outdated("2023.04.0"))
+	assert.False(t, strategy.Outdated("1.2.3"))
+}
+
+func TestCalVer_Redate(t *testing.T) {
+	strategy := newTestCalVer(t, "YYYY.0M.MICRO", time.Date(2024, time.April, 2, 12, 0, 0, 0, time.UTC)).(DateStrategy)
+
+	tests := []struct {
+		name     string
+		releases git.Releases
+		version  string
+		want     string
+	}{
+		{
+			name:     "first release of the period",
+			releases: git.Releases{Latest: &git.Tag{Name: "2024.03.0"}, Stable: &git.Tag{Name: "2024.03.0"}},
+			version:  "2024.03.1",
+			want:     "2024.04.0",
+		},
+		{
+			name:     "period already released",
+			releases: git.Releases{Latest: &git.Tag{Name: "2024.04.0"}, Stable: &git.Tag{Name: "2024.04.0"}},
+			version:  "2024.03.1",
+			want:     "2024.04.1",
+		},
+		{
+			name:     "pre-release",
+			releases: git.Releases{Latest: &git.Tag{Name: "2024.03.0"}, Stable: &git.Tag{Name: "2024.03.0"}},
+			version:  "2024.03.1-rc.0",
+			want:     "2024.04.0-rc.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := strategy.Redate(tt.releases, tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package versioning
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+var versionLineRegex = regexp.MustCompile(`^v?((?:\d+\.)+)x$`)
+
+// VersionLine is the common beginning of all versions released from a maintenance branch, eg. `1.` for the line
+// `1.x`. The zero value contains all versions.
+type VersionLine string
+
+// VersionLineFromBranch returns the version line of maintenance branches like `release/1.x`, `1.2.x` or `v1.x`. The
+// last segment of the branch name must be a version that ends in `.x`. For all other branches the zero value is
+// returned.
+func VersionLineFromBranch(branch string) VersionLine {
+	matches := versionLineRegex.FindStringSubmatch(path.Base(branch))
+	if matches == nil {
+		return ""
+	}
+
+	return VersionLine(matches[1])
+}
+
+// Contains checks if the version is part of the line.
+func (l VersionLine) Contains(version string) bool {
+	return strings.HasPrefix(version, string(l))
+}
+
+func (l VersionLine) String() string {
+	if l == "" {
+		return "*"
+	}
+
+	return string(l) + "x"
+}
@@ -0,0 +1,38 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionLineFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   VersionLine
+	}{
+		{branch: "main", want: ""},
+		{branch: "release/1.x", want: "1."},
+		{branch: "release/v1.x", want: "1."},
+		{branch: "1.2.x", want: "1.2."},
+		{branch: "maintenance/2024.x", want: "2024."},
+		{branch: "release/1.x/docs", want: ""},
+		{branch: "release/x", want: ""},
+		{branch: "release/1.2", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			assert.Equal(t, tt.want, VersionLineFromBranch(tt.branch))
+		})
+	}
+}
+
+func TestVersionLine_Contains(t *testing.T) {
+	assert.True(t, VersionLine("1.").Contains("1.9.3"))
+	assert.True(t, VersionLine("1.").Contains("1.10.0-rc.0"))
+	assert.False(t, VersionLine("1.").Contains("2.0.0"))
+	assert.False(t, VersionLine("1.").Contains("10.0.0"))
+	assert.True(t, VersionLine("1.2.").Contains("1.2.4"))
+	assert.False(t, VersionLine("1.2.").Contains("1.3.0"))
+	assert.True(t, VersionLine("").Contains("2.0.0"))
+}
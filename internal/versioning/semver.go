@@ -12,24 +12,37 @@ import (
 
 var SemVer Strategy = semVer{}
 
-type semVer struct{}
+// SemVerPreMajor is SemVer with lower bumps while the major version is 0, see Strategy.Bump: breaking changes bump the
+// minor version and features bump the patch version. It never proposes v1.0.0 on its own, the major version needs to be bumped
+// explicitly.
+var SemVerPreMajor Strategy = semVer{preMajorBumps: true}
 
-func (s semVer) NextVersion(r git.Releases, versionBump VersionBump, nextVersionType NextVersionType) (string, error) {
-	latest, err := parseSemverWithDefault(r.Latest)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse latest version: %w", err)
+type semVer struct {
+	preMajorBumps bool
+}
+
+// Bump lowers the bump while the major version is 0 if pre-major bumps are enabled.
+func (s semVer) Bump(r git.Releases, versionBump VersionBump) (VersionBump, error) {
+	if !s.preMajorBumps {
+		return versionBump, nil
 	}
 
-	stable, err := parseSemverWithDefault(r.Stable)
+	_, next, err := parseReleases(r)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse stable version: %w", err)
+		return UnknownVersion, err
 	}
 
-	// If there is a previous stable release, we use that as the version anchor. Falling back to any pre-releases
-	// if they are the only tags in the repo.
-	next := latest
-	if r.Stable != nil {
-		next = stable
+	if next.Major == 0 {
+		return preMajorBump(versionBump), nil
+	}
+
+	return versionBump, nil
+}
+
+func (s semVer) NextVersion(r git.Releases, versionBump VersionBump, nextVersionType NextVersionType) (string, error) {
+	latest, next, err := parseReleases(r)
+	if err != nil {
+		return "", err
 	}
 
 	switch versionBump {
@@ -62,21 +75,42 @@ func (s semVer) NextVersion(r git.Releases, versionBump VersionBump, nextVersion
 		setPRVersion(&next, nextVersionType.String(), id)
 	}
 
-	return "v" + next.String(), nil
+	return next.String(), nil
+}
+
+// parseReleases parses the latest version and the version that the next version is based on.
+func parseReleases(r git.Releases) (latest, anchor semver.Version, err error) {
+	latest, err = parseSemverWithDefault(r.Latest)
+	if err != nil {
+		return latest, anchor, fmt.Errorf("failed to parse latest version: %w", err)
+	}
+
+	stable, err := parseSemverWithDefault(r.Stable)
+	if err != nil {
+		return latest, anchor, fmt.Errorf("failed to parse stable version: %w", err)
+	}
+
+	// If there is a previous stable release, we use that as the version anchor. Falling back to any pre-releases
+	// if they are the only tags in the repo.
+	anchor = latest
+	if r.Stable != nil {
+		anchor = stable
+	}
+
+	return latest, anchor, nil
 }
 
-func BumpFromCommits(commits []commitparser.AnalyzedCommit) VersionBump {
+// BumpFromCommits returns the highest version bump of the commits. The bump of a commit is taken from its type,
+// breaking changes always bump the major version. If no commit triggers a bump, UnknownVersion is returned.
+func BumpFromCommits(commits []commitparser.AnalyzedCommit, types commitparser.CommitTypes) VersionBump {
 	bump := UnknownVersion
 
 	for _, commit := range commits {
 		entryBump := UnknownVersion
-		switch {
-		case commit.BreakingChange:
+		if commit.BreakingChange {
 			entryBump = MajorVersion
-		case commit.Type == "feat":
-			entryBump = MinorVersion
-		case commit.Type == "fix":
-			entryBump = PatchVersion
+		} else if commitType, ok := types.Get(commit.Type); ok {
+			entryBump = bumpFromType(commitType.Bump)
 		}
 
 		if entryBump > bump {
@@ -87,6 +121,31 @@ func BumpFromCommits(commits []commitparser.AnalyzedCommit) VersionBump {
 	return bump
 }
 
+// preMajorBump returns the bump for versions below 1.0.0, where every bump is one level lower.
+func preMajorBump(bump VersionBump) VersionBump {
+	switch bump {
+	case MajorVersion:
+		return MinorVersion
+	case MinorVersion:
+		return PatchVersion
+	default:
+		return bump
+	}
+}
+
+func bumpFromType(bump commitparser.Bump) VersionBump {
+	switch bump {
+	case commitparser.BumpPatch:
+		return PatchVersion
+	case commitparser.BumpMinor:
+		return MinorVersion
+	case commitparser.BumpMajor:
+		return MajorVersion
+	default:
+		return UnknownVersion
+	}
+}
+
 func setPRVersion(version *semver.Version, prType string, count uint64) {
 	version.Pre = []semver.PRVersion{
 		{VersionStr: prType},
@@ -95,14 +154,11 @@ func setPRVersion(version *semver.Version, prType string, count uint64) {
 }
 
 func parseSemverWithDefault(tag *git.Tag) (semver.Version, error) {
-	version := "v0.0.0"
+	version := "0.0.0"
 	if tag != nil {
 		version = tag.Name
 	}
 
-	// The lib can not handle v prefixes
-	version = strings.TrimPrefix(version, "v")
-
 	parsedVersion, err := semver.Parse(version)
 	if err != nil {
 		return semver.Version{}, fmt.Errorf("failed to parse version %q: %w", version, err)
@@ -123,3 +179,18 @@ func (s semVer) IsPrerelease(version string) bool {
 
 	return false
 }
+
+func (s semVer) IsValid(version string) bool {
+	_, err := parseSemverWithDefault(&git.Tag{Name: version})
+	return err == nil
+}
+
+func (s semVer) Compare(a, b string) int {
+	versionA, errA := parseSemverWithDefault(&git.Tag{Name: a})
+	versionB, errB := parseSemverWithDefault(&git.Tag{Name: b})
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	return versionA.Compare(versionB)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/apricote/releaser-pleaser/internal/commitparser"
 	"github.com/apricote/releaser-pleaser/internal/git"
@@ -26,182 +27,182 @@ func TestSemVer_NextVersion(t *testing.T) {
 			name: "simple bump (major)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v2.0.0",
+			want:    "2.0.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "simple bump (minor)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v1.2.0",
+			want:    "1.2.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "simple bump (patch)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v1.1.2",
+			want:    "1.1.2",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "normal to prerelease  (major)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v2.0.0-rc.0",
+			want:    "2.0.0-rc.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "normal to prerelease  (minor)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.2.0-rc.0",
+			want:    "1.2.0-rc.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "normal to prerelease  (patch)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.1.2-rc.0",
+			want:    "1.1.2-rc.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease bump (major)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v2.0.0-rc.0"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "2.0.0-rc.0"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v2.0.0-rc.1",
+			want:    "2.0.0-rc.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease bump (minor)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.2.0-rc.0"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.2.0-rc.0"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.2.0-rc.1",
+			want:    "1.2.0-rc.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease bump (patch)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.2-rc.0"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.2-rc.0"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.1.2-rc.1",
+			want:    "1.1.2-rc.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease different bump (major)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.2.0-rc.0"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.2.0-rc.0"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v2.0.0-rc.1",
+			want:    "2.0.0-rc.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease different bump (minor)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.2-rc.0"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.2-rc.0"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.2.0-rc.1",
+			want:    "1.2.0-rc.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease to prerelease",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-alpha.2"},
-					Stable: &git.Tag{Name: "v1.1.0"},
+					Latest: &git.Tag{Name: "1.1.1-alpha.2"},
+					Stable: &git.Tag{Name: "1.1.0"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeRC,
 			},
-			want:    "v1.1.1-rc.0",
+			want:    "1.1.1-rc.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease to normal (explicit)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-alpha.2"},
-					Stable: &git.Tag{Name: "v1.1.0"},
+					Latest: &git.Tag{Name: "1.1.1-alpha.2"},
+					Stable: &git.Tag{Name: "1.1.0"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeNormal,
 			},
-			want:    "v1.1.1",
+			want:    "1.1.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "prerelease to normal (implicit)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-alpha.2"},
-					Stable: &git.Tag{Name: "v1.1.0"},
+					Latest: &git.Tag{Name: "1.1.1-alpha.2"},
+					Stable: &git.Tag{Name: "1.1.0"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v1.1.1",
+			want:    "1.1.1",
 			wantErr: assert.NoError,
 		},
 		{
@@ -214,7 +215,7 @@ func TestSemVer_NextVersion(t *testing.T) {
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v1.0.0",
+			want:    "1.0.0",
 			wantErr: assert.NoError,
 		},
 		{
@@ -227,7 +228,7 @@ func TestSemVer_NextVersion(t *testing.T) {
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v0.1.0",
+			want:    "0.1.0",
 			wantErr: assert.NoError,
 		},
 		{
@@ -240,47 +241,47 @@ func TestSemVer_NextVersion(t *testing.T) {
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v0.0.1",
+			want:    "0.0.1",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "nil stable release (major)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-rc.0"},
+					Latest: &git.Tag{Name: "1.1.1-rc.0"},
 					Stable: nil,
 				},
 				versionBump:     MajorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v2.0.0",
+			want:    "2.0.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "nil stable release (minor)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-rc.0"},
+					Latest: &git.Tag{Name: "1.1.1-rc.0"},
 					Stable: nil,
 				},
 				versionBump:     MinorVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
-			want:    "v1.2.0",
+			want:    "1.2.0",
 			wantErr: assert.NoError,
 		},
 		{
 			name: "nil stable release (patch)",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-rc.0"},
+					Latest: &git.Tag{Name: "1.1.1-rc.0"},
 					Stable: nil,
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeUndefined,
 			},
 			// TODO: Is this actually correct our should it be v1.1.1?
-			want:    "v1.1.2",
+			want:    "1.1.2",
 			wantErr: assert.NoError,
 		},
 		{
@@ -300,8 +301,8 @@ func TestSemVer_NextVersion(t *testing.T) {
 			name: "error on invalid tag prerelease",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1-rc.foo"},
-					Stable: &git.Tag{Name: "v1.1.1-rc.foo"},
+					Latest: &git.Tag{Name: "1.1.1-rc.foo"},
+					Stable: &git.Tag{Name: "1.1.1-rc.foo"},
 				},
 				versionBump:     PatchVersion,
 				nextVersionType: NextVersionTypeRC,
@@ -313,8 +314,8 @@ func TestSemVer_NextVersion(t *testing.T) {
 			name: "error on invalid bump",
 			args: args{
 				releases: git.Releases{
-					Latest: &git.Tag{Name: "v1.1.1"},
-					Stable: &git.Tag{Name: "v1.1.1"},
+					Latest: &git.Tag{Name: "1.1.1"},
+					Stable: &git.Tag{Name: "1.1.1"},
 				},
 
 				versionBump:     UnknownVersion,
@@ -335,10 +336,91 @@ func TestSemVer_NextVersion(t *testing.T) {
 	}
 }
 
+func TestSemVerPreMajor(t *testing.T) {
+	tests := []struct {
+		name            string
+		releases        git.Releases
+		versionBump     VersionBump
+		nextVersionType NextVersionType
+		wantBump        VersionBump
+		want            string
+	}{
+		{
+			name:        "no tags (major)",
+			releases:    git.Releases{},
+			versionBump: MajorVersion,
+			wantBump:    MinorVersion,
+			want:        "0.1.0",
+		},
+		{
+			name:        "no tags (minor)",
+			releases:    git.Releases{},
+			versionBump: MinorVersion,
+			wantBump:    PatchVersion,
+			want:        "0.0.1",
+		},
+		{
+			name:        "pre 1.0 (major)",
+			releases:    git.Releases{Latest: &git.Tag{Name: "0.3.2"}, Stable: &git.Tag{Name: "0.3.2"}},
+			versionBump: MajorVersion,
+			wantBump:    MinorVersion,
+			want:        "0.4.0",
+		},
+		{
+			name:        "pre 1.0 (minor)",
+			releases:    git.Releases{Latest: &git.Tag{Name: "0.3.2"}, Stable: &git.Tag{Name: "0.3.2"}},
+			versionBump: MinorVersion,
+			wantBump:    PatchVersion,
+			want:        "0.3.3",
+		},
+		{
+			name:        "pre 1.0 (patch)",
+			releases:    git.Releases{Latest: &git.Tag{Name: "0.3.2"}, Stable: &git.Tag{Name: "0.3.2"}},
+			versionBump: PatchVersion,
+			wantBump:    PatchVersion,
+			want:        "0.3.3",
+		},
+		{
+			name:            "pre 1.0 prerelease (major)",
+			releases:        git.Releases{Latest: &git.Tag{Name: "0.4.0-rc.0"}, Stable: &git.Tag{Name: "0.3.2"}},
+			versionBump:     MajorVersion,
+			nextVersionType: NextVersionTypeRC,
+			wantBump:        MinorVersion,
+			want:            "0.4.0-rc.1",
+		},
+		{
+			name:        "post 1.0 (major)",
+			releases:    git.Releases{Latest: &git.Tag{Name: "1.3.2"}, Stable: &git.Tag{Name: "1.3.2"}},
+			versionBump: MajorVersion,
+			wantBump:    MajorVersion,
+			want:        "2.0.0",
+		},
+		{
+			name:        "post 1.0 (minor)",
+			releases:    git.Releases{Latest: &git.Tag{Name: "1.3.2"}, Stable: &git.Tag{Name: "1.3.2"}},
+			versionBump: MinorVersion,
+			wantBump:    MinorVersion,
+			want:        "1.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bump, err := SemVerPreMajor.Bump(tt.releases, tt.versionBump)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBump, bump)
+
+			got, err := SemVerPreMajor.NextVersion(tt.releases, bump, tt.nextVersionType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestVersionBumpFromCommits(t *testing.T) {
 	tests := []struct {
 		name            string
 		analyzedCommits []commitparser.AnalyzedCommit
+		types           commitparser.CommitTypes
 		want            VersionBump
 	}{
 		{
@@ -381,10 +463,39 @@ func TestVersionBumpFromCommits(t *testing.T) {
 			analyzedCommits: []commitparser.AnalyzedCommit{{Type: "docs"}, {Type: "fix"}},
 			want:            PatchVersion,
 		},
+		{
+			name:            "custom type (patch)",
+			analyzedCommits: []commitparser.AnalyzedCommit{{Type: "perf"}},
+			types:           commitparser.CommitTypes{{Type: "perf", Bump: commitparser.BumpPatch}},
+			want:            PatchVersion,
+		},
+		{
+			name:            "custom type without bump (unknown)",
+			analyzedCommits: []commitparser.AnalyzedCommit{{Type: "refactor"}},
+			types:           commitparser.CommitTypes{{Type: "refactor", Bump: commitparser.BumpNone}},
+			want:            UnknownVersion,
+		},
+		{
+			name:            "custom bump of default type (major)",
+			analyzedCommits: []commitparser.AnalyzedCommit{{Type: "feat"}},
+			types:           commitparser.CommitTypes{{Type: "feat", Bump: commitparser.BumpMajor}},
+			want:            MajorVersion,
+		},
+		{
+			name:            "hidden type (minor)",
+			analyzedCommits: []commitparser.AnalyzedCommit{{Type: "deps"}},
+			types:           commitparser.CommitTypes{{Type: "deps", Hidden: true, Bump: commitparser.BumpMinor}},
+			want:            MinorVersion,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, BumpFromCommits(tt.analyzedCommits), "BumpFromCommits(%v)", tt.analyzedCommits)
+			types := tt.types
+			if types == nil {
+				types = commitparser.DefaultCommitTypes
+			}
+
+			assert.Equalf(t, tt.want, BumpFromCommits(tt.analyzedCommits, types), "BumpFromCommits(%v)", tt.analyzedCommits)
 		})
 	}
 }
@@ -402,12 +513,12 @@ func TestSemVer_IsPrerelease(t *testing.T) {
 		},
 		{
 			name:    "stable version",
-			version: "v1.0.0",
+			version: "1.0.0",
 			want:    false,
 		},
 		{
 			name:    "pre-release version",
-			version: "v1.0.0-rc.1+foo",
+			version: "1.0.0-rc.1+foo",
 			want:    true,
 		},
 		{
@@ -422,3 +533,18 @@ func TestSemVer_IsPrerelease(t *testing.T) {
 		})
 	}
 }
+
+func TestSemVer_Compare(t *testing.T) {
+	assert.Negative(t, SemVer.Compare("1.9.0", "1.10.0"))
+	assert.Positive(t, SemVer.Compare("1.0.0", "1.0.0-rc.1"))
+	assert.Zero(t, SemVer.Compare("1.0.0", "1.0.0"))
+}
+
+func TestSemVer_IsValid(t *testing.T) {
+	assert.True(t, SemVer.IsValid("1.0.0"))
+	assert.True(t, SemVer.IsValid("1.0.0-rc.0"))
+	// The prefix is part of the tag template
+	assert.False(t, SemVer.IsValid("v1.0.0"))
+	assert.False(t, SemVer.IsValid("2024.01"))
+	assert.False(t, SemVer.IsValid("latest"))
+}
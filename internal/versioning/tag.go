@@ -0,0 +1,73 @@
+package versioning
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DefaultSemVerTagTemplate is the tag template used with SemVer if no template is configured.
+	DefaultSemVerTagTemplate = "v{{.Version}}"
+	// DefaultCalVerTagTemplate is the tag template used with CalVer if no template is configured.
+	DefaultCalVerTagTemplate = "{{.Version}}"
+)
+
+// versionPlaceholder is rendered in place of the version to split the template into the parts before and after it.
+const versionPlaceholder = "\x00"
+
+// TagTemplate builds tag names from versions and extracts the version from tag names. The zero value uses the version
+// as the tag name.
+type TagTemplate struct {
+	prefix string
+	suffix string
+}
+
+// TagTemplateData is passed to the template in NewTagTemplate.
+type TagTemplateData struct {
+	// Version is the version returned by the Strategy, eg. `1.2.3` or `2024.01.0`.
+	Version string
+	// Component is the name of the component, it is empty for the root component.
+	Component string
+}
+
+// NewTagTemplate parses a Go template like `v{{.Version}}` or `{{.Component}}@{{.Version}}`. The template is rendered
+// with TagTemplateData and must contain the version exactly once.
+func NewTagTemplate(text, component string) (TagTemplate, error) {
+	tpl, err := template.New("tag").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return TagTemplate{}, fmt.Errorf("failed to parse tag template %q: %w", text, err)
+	}
+
+	var rendered strings.Builder
+	if err = tpl.Execute(&rendered, TagTemplateData{Version: versionPlaceholder, Component: component}); err != nil {
+		return TagTemplate{}, fmt.Errorf("failed to render tag template %q: %w", text, err)
+	}
+
+	prefix, suffix, ok := strings.Cut(rendered.String(), versionPlaceholder)
+	if !ok || strings.Contains(suffix, versionPlaceholder) {
+		return TagTemplate{}, fmt.Errorf("tag template %q must contain {{.Version}} exactly once", text)
+	}
+
+	return TagTemplate{prefix: prefix, suffix: suffix}, nil
+}
+
+// Tag returns the tag name for the version.
+func (t TagTemplate) Tag(version string) string {
+	return t.prefix + version + t.suffix
+}
+
+// Version returns the part of the tag that is the version. It returns false if the tag does not match the template.
+func (t TagTemplate) Version(tag string) (string, bool) {
+	version, ok := strings.CutPrefix(tag, t.prefix)
+	if !ok {
+		return "", false
+	}
+
+	version, ok = strings.CutSuffix(version, t.suffix)
+	if !ok || version == "" {
+		return "", false
+	}
+
+	return version, true
+}
@@ -0,0 +1,100 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTagTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		component string
+		wantTag   string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "default semver",
+			text:    DefaultSemVerTagTemplate,
+			wantTag: "v1.2.3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "default calver",
+			text:    DefaultCalVerTagTemplate,
+			wantTag: "1.2.3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "prefix",
+			text:    "release-{{.Version}}",
+			wantTag: "release-1.2.3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "component",
+			text:      "{{.Component}}@{{.Version}}",
+			component: "myapp",
+			wantTag:   "myapp@1.2.3",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:    "suffix",
+			text:    "v{{.Version}}-final",
+			wantTag: "v1.2.3-final",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "missing version",
+			text:    "release",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "version twice",
+			text:    "{{.Version}}-{{.Version}}",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown field",
+			text:    "{{.Name}}/{{.Version}}",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid template",
+			text:    "{{.Version",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTagTemplate(tt.text, tt.component)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantTag, got.Tag("1.2.3"))
+		})
+	}
+}
+
+func TestTagTemplate_Version(t *testing.T) {
+	template, err := NewTagTemplate("release-{{.Version}}-final", "")
+	require.NoError(t, err)
+
+	version, ok := template.Version("release-1.2.3-final")
+	assert.True(t, ok)
+	assert.Equal(t, "1.2.3", version)
+
+	_, ok = template.Version("release-1.2.3")
+	assert.False(t, ok)
+	_, ok = template.Version("v1.2.3-final")
+	assert.False(t, ok)
+	_, ok = template.Version("release--final")
+	assert.False(t, ok)
+
+	version, ok = TagTemplate{}.Version("1.2.3")
+	assert.True(t, ok)
+	assert.Equal(t, "1.2.3", version)
+}
@@ -7,8 +7,50 @@ import (
 )
 
 type Strategy interface {
+	// Bump returns the version bump that is applied for the bump of the commits, e.g. SemVerPreMajor lowers it while
+	// the major version is 0. The result is passed to NextVersion.
+	Bump(git.Releases, VersionBump) (VersionBump, error)
 	NextVersion(git.Releases, VersionBump, NextVersionType) (string, error)
 	IsPrerelease(version string) bool
+	// IsValid checks if the version has the format of the strategy.
+	IsValid(version string) bool
+	// Compare returns a negative number if version a is lower than b, a positive number if a is greater and 0 if they
+	// are equal. Both versions must be valid.
+	Compare(a, b string) int
+}
+
+// DateStrategy is implemented by strategies that contain the release date in the version. The version of a release
+// pull request is calculated when the pull request is updated, so it can be outdated when the pull request is merged.
+type DateStrategy interface {
+	// Outdated checks if the version was calculated in an earlier period than the current date.
+	Outdated(version string) bool
+	// Redate returns the version for a release at the current date.
+	Redate(r git.Releases, version string) (string, error)
+}
+
+var _ git.ReleaseTags = ReleaseTags{}
+
+// ReleaseTags are the tags that match Template with a valid version of Strategy.
+type ReleaseTags struct {
+	Template TagTemplate
+	Strategy Strategy
+}
+
+func (t ReleaseTags) Version(tag string) (string, bool) {
+	version, ok := t.Template.Version(tag)
+	if !ok || !t.Strategy.IsValid(version) {
+		return "", false
+	}
+
+	return version, true
+}
+
+func (t ReleaseTags) IsPrerelease(version string) bool {
+	return t.Strategy.IsPrerelease(version)
+}
+
+func (t ReleaseTags) Compare(a, b string) int {
+	return t.Strategy.Compare(a, b)
 }
 
 type VersionBump conventionalcommits.VersionBump
@@ -20,6 +62,21 @@ const (
 	MajorVersion
 )
 
+func (b VersionBump) String() string {
+	switch b {
+	case PatchVersion:
+		return "patch"
+	case MinorVersion:
+		return "minor"
+	case MajorVersion:
+		return "major"
+	case UnknownVersion:
+		return "unknown"
+	default:
+		return ""
+	}
+}
+
 type NextVersionType int
 
 const (
@@ -0,0 +1,84 @@
+package versioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseTags_Version(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		strategy Strategy
+		tag      string
+		want     string
+		wantOk   bool
+	}{
+		{
+			name:     "default template",
+			template: DefaultSemVerTagTemplate,
+			strategy: SemVer,
+			tag:      "v1.2.3",
+			want:     "1.2.3",
+			wantOk:   true,
+		},
+		{
+			name:     "component",
+			template: "{{.Component}}/v{{.Version}}",
+			strategy: SemVer,
+			tag:      "api/v1.2.3",
+			want:     "1.2.3",
+			wantOk:   true,
+		},
+		{
+			name:     "other component",
+			template: "{{.Component}}/v{{.Version}}",
+			strategy: SemVer,
+			tag:      "web/v1.2.3",
+			wantOk:   false,
+		},
+		{
+			name:     "without prefix",
+			template: "{{.Version}}",
+			strategy: SemVer,
+			tag:      "1.2.3",
+			want:     "1.2.3",
+			wantOk:   true,
+		},
+		{
+			name:     "prefix not in template",
+			template: "{{.Version}}",
+			strategy: SemVer,
+			tag:      "v1.2.3",
+			wantOk:   false,
+		},
+		{
+			name:     "invalid version",
+			template: DefaultSemVerTagTemplate,
+			strategy: SemVer,
+			tag:      "vlatest",
+			wantOk:   false,
+		},
+		{
+			name:     "calver",
+			template: "release-{{.Version}}",
+			strategy: newTestCalVer(t, "", time.Now()),
+			tag:      "release-2024.01.0",
+			want:     "2024.01.0",
+			wantOk:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := NewTagTemplate(tt.template, "api")
+			require.NoError(t, err)
+
+			got, ok := ReleaseTags{Template: template, Strategy: tt.strategy}.Version(tt.tag)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/apricote/releaser-pleaser/internal/changelog"
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+	"github.com/apricote/releaser-pleaser/internal/versioning"
+)
+
+type PreviewResult struct {
+	Component Component
+	// Version is empty if there are no releasable commits for the component.
+	Version   string
+	Tag       string
+	Changelog string
+}
+
+// Preview calculates the next version and changelog entry of every component from a local repository. It does not
+// talk to a forge, so the overrides from pull request descriptions and labels are not considered.
+func Preview(ctx context.Context, logger *slog.Logger, repo *git.Repository, commitParser commitparser.CommitParser, commitTypes commitparser.CommitTypes, versioningStrategy versioning.Strategy, components []Component, templates changelog.Templates) ([]PreviewResult, error) {
+	results := make([]PreviewResult, 0, len(components))
+
+	for _, component := range components {
+		result, err := previewComponent(ctx, logger, repo, commitParser, commitTypes, versioningStrategy, component, templates)
+		if err != nil {
+			if component.Name != "" {
+				return nil, fmt.Errorf("failed to preview component %q: %w", component.Name, err)
+			}
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func previewComponent(ctx context.Context, logger *slog.Logger, repo *git.Repository, commitParser commitparser.CommitParser, commitTypes commitparser.CommitTypes, versioningStrategy versioning.Strategy, component Component, templates changelog.Templates) (PreviewResult, error) {
+	result := PreviewResult{Component: component}
+
+	releases, err := repo.LatestTags(ctx, component.releaseTags(versioningStrategy))
+	if err != nil {
+		return result, err
+	}
+
+	commits, err := repo.CommitsSince(ctx, releases.Stable)
+	if err != nil {
+		return result, err
+	}
+
+	if component.cleanPath() != "" {
+		commits, err = filterCommitsByPath(ctx, component, repo, commits)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	analyzedCommits, err := commitParser.Analyze(commits)
+	if err != nil {
+		return result, err
+	}
+
+	releaseVersions := component.tagVersions(releases)
+
+	versionBump, err := versioningStrategy.Bump(releaseVersions, versioning.BumpFromCommits(analyzedCommits, commitTypes))
+	if err != nil {
+		return result, err
+	}
+	result.Version, err = forcedVersion(logger, versioningStrategy, component, releaseVersions, releasepr.ReleaseOverrides{}, analyzedCommits)
+	if err != nil {
+		return result, err
+	}
+
+	if result.Version == "" {
+		if versionBump == versioning.UnknownVersion {
+			return result, nil
+		}
+
+		result.Version, err = versioningStrategy.NextVersion(releaseVersions, versionBump, versioning.NextVersionTypeUndefined)
+		if err != nil {
+			return result, err
+		}
+	}
+	result.Tag = component.TagTemplate.Tag(result.Version)
+
+	changelogData := changelog.New(commitparser.ByType(analyzedCommits), commitTypes, result.Tag, "", "", "", "")
+	changelogData.ReleaseDate = time.Now().UTC()
+	changelogData.Bump = versionBump.String()
+	if releases.Stable != nil {
+		changelogData.PreviousVersion = releases.Stable.Name
+	}
+
+	result.Changelog, err = changelog.Entry(logger, changelog.OrDefault(templates.Changelog), changelogData, changelog.Formatting{})
+	if err != nil {
+		return result, fmt.Errorf("failed to build changelog entry: %w", err)
+	}
+
+	return result, nil
+}
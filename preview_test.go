@@ -0,0 +1,178 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/changelog"
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+	"github.com/apricote/releaser-pleaser/internal/commitparser/conventionalcommits"
+	"github.com/apricote/releaser-pleaser/internal/forge/fake"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/versioning"
+)
+
+func TestPreview(t *testing.T) {
+	type previewCommit struct {
+		message string
+		files   map[string]string
+		tag     string
+	}
+
+	tests := []struct {
+		name       string
+		commits    []previewCommit
+		components []Component
+		want       []PreviewResult
+		// wantChangelog are substrings of the changelogs of the results.
+		wantChangelog [][]string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "no tags",
+			commits:    []previewCommit{{message: "fix: bar"}},
+			components: []Component{{}},
+			want:       []PreviewResult{{Version: "0.0.1", Tag: "v0.0.1"}},
+			wantChangelog: [][]string{
+				{"## v0.0.1", "### Bug Fixes", "bar"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "feat bump",
+			commits: []previewCommit{
+				{message: "fix: old", tag: "v1.2.3"},
+				{message: "feat: foo"},
+				{message: "fix: bar"},
+			},
+			components: []Component{{}},
+			want:       []PreviewResult{{Version: "1.3.0", Tag: "v1.3.0"}},
+			wantChangelog: [][]string{
+				{"## v1.3.0", "### Features", "foo", "### Bug Fixes", "bar"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "release-as footer",
+			commits: []previewCommit{
+				{message: "fix: old", tag: "v1.2.3"},
+				{message: "chore: prepare stable release\n\nRelease-As: 2.0.0"},
+			},
+			components: []Component{{}},
+			want:       []PreviewResult{{Version: "2.0.0", Tag: "v2.0.0"}},
+			wantChangelog: [][]string{
+				{"## v2.0.0"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid release-as footer",
+			commits: []previewCommit{
+				{message: "fix: old", tag: "v1.2.3"},
+				{message: "chore: release\n\nRelease-As: next"},
+			},
+			components: []Component{{}},
+			wantErr:    assert.Error,
+		},
+		{
+			name: "release-as footer of released version",
+			commits: []previewCommit{
+				{message: "fix: old", tag: "v1.2.3"},
+				{message: "fix: bar\n\nRelease-As: 1.0.0"},
+			},
+			components: []Component{{}},
+			want:       []PreviewResult{{Version: "1.2.4", Tag: "v1.2.4"}},
+			wantChangelog: [][]string{
+				{"## v1.2.4", "bar"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "no releasable commits",
+			commits: []previewCommit{
+				{message: "feat: foo", tag: "v1.0.0"},
+				{message: "chore: update dependencies"},
+			},
+			components:    []Component{{}},
+			want:          []PreviewResult{{}},
+			wantChangelog: [][]string{nil},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "component path",
+			commits: []previewCommit{
+				{message: "chore: init components", files: map[string]string{"api/main.go": "package main", "web/index.html": "<html>"}},
+				{message: "feat: api feature", files: map[string]string{"api/main.go": "package main // foo"}},
+				{message: "fix: web fix", files: map[string]string{"web/index.html": "<html><body>"}},
+			},
+			components: []Component{
+				{Name: "api", Path: "api"},
+				{Name: "web", Path: "web"},
+				{Name: "docs", Path: "docs"},
+			},
+			want: []PreviewResult{
+				{Version: "0.1.0", Tag: "api/v0.1.0"},
+				{Version: "0.0.1", Tag: "web/v0.0.1"},
+				{},
+			},
+			wantChangelog: [][]string{
+				{"## api/v0.1.0", "api feature"},
+				{"## web/v0.0.1", "web fix"},
+				nil,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := slog.New(slog.DiscardHandler)
+
+			remote, err := fake.NewRemote(fmt.Sprintf("preview-%d", i), "main")
+			require.NoError(t, err)
+			t.Cleanup(remote.Close)
+
+			for _, c := range tt.commits {
+				hash, err := remote.Commit("main", c.message, c.files)
+				require.NoError(t, err)
+				if c.tag != "" {
+					require.NoError(t, remote.Tag(c.tag, hash))
+				}
+			}
+
+			for j := range tt.components {
+				text := versioning.DefaultSemVerTagTemplate
+				if tt.components[j].Name != "" {
+					text = "{{.Component}}/" + text
+				}
+				tt.components[j].TagTemplate = newTestTagTemplate(t, text, tt.components[j].Name)
+			}
+
+			repo := git.NewRepository(logger, remote.Repository(), nil)
+			got, err := Preview(ctx, logger, repo, conventionalcommits.NewParser(logger, commitparser.DefaultCommitTypes), commitparser.DefaultCommitTypes, versioning.SemVer, tt.components, changelog.Templates{})
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			require.Len(t, got, len(tt.want))
+			for j, want := range tt.want {
+				assert.Equal(t, tt.components[j].Name, got[j].Component.Name)
+				assert.Equal(t, want.Version, got[j].Version)
+				assert.Equal(t, want.Tag, got[j].Tag)
+
+				if tt.wantChangelog[j] == nil {
+					assert.Empty(t, got[j].Changelog)
+				}
+				for _, want := range tt.wantChangelog[j] {
+					assert.Contains(t, got[j].Changelog, want)
+				}
+			}
+		})
+	}
+}
@@ -4,19 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apricote/releaser-pleaser/internal/assets"
 	"github.com/apricote/releaser-pleaser/internal/changelog"
 	"github.com/apricote/releaser-pleaser/internal/commitparser"
 	"github.com/apricote/releaser-pleaser/internal/forge"
 	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/hook"
 	"github.com/apricote/releaser-pleaser/internal/releasepr"
 	"github.com/apricote/releaser-pleaser/internal/updater"
 	"github.com/apricote/releaser-pleaser/internal/versioning"
 )
 
 const (
-	PullRequestBranchFormat = "releaser-pleaser--branches--%s"
+	PullRequestBranchFormat          = "releaser-pleaser--branches--%s"
+	PullRequestComponentBranchFormat = "releaser-pleaser--branches--%s--components--%s"
 )
 
 const (
@@ -31,47 +42,92 @@ type ReleaserPleaser struct {
 	forge        forge.Forge
 	logger       *slog.Logger
 	targetBranch string
+	// versionLine restricts the versions on maintenance branches like `release/1.x`. It is derived from the
+	// targetBranch and empty for all other branches.
+	versionLine  versioning.VersionLine
 	commitParser commitparser.CommitParser
+	commitTypes  commitparser.CommitTypes
 	versioning   versioning.Strategy
-	extraFiles   []string
-	updaters     []updater.Updater
+	components   []Component
+	labels       []releasepr.Label
+	templates    changelog.Templates
+
+	// dryRun skips pushing the release branch and writes the diff of the release commit to output instead. The forge
+	// is expected to be wrapped in dryrun.DryRun by the caller. output should not be the destination of the result, so
+	// that the diff is not mixed with it.
+	dryRun bool
+	output io.Writer
 }
 
-func New(forge forge.Forge, logger *slog.Logger, targetBranch string, commitParser commitparser.CommitParser, versioningStrategy versioning.Strategy, extraFiles []string, updaters []updater.Updater) *ReleaserPleaser {
+func New(forge forge.Forge, logger *slog.Logger, targetBranch string, commitParser commitparser.CommitParser, commitTypes commitparser.CommitTypes, versioningStrategy versioning.Strategy, components []Component, labels []releasepr.Label, templates changelog.Templates, dryRun bool, output io.Writer) *ReleaserPleaser {
 	return &ReleaserPleaser{
 		forge:        forge,
 		logger:       logger,
 		targetBranch: targetBranch,
+		versionLine:  versioning.VersionLineFromBranch(targetBranch),
 		commitParser: commitParser,
+		commitTypes:  commitTypes,
 		versioning:   versioningStrategy,
-		extraFiles:   extraFiles,
-		updaters:     updaters,
+		components:   components,
+		labels:       labels,
+		templates:    templates,
+		dryRun:       dryRun,
+		output:       output,
 	}
 }
 
 func (rp *ReleaserPleaser) EnsureLabels(ctx context.Context) error {
 	// TODO: Wrap Error
 
-	return rp.forge.EnsureLabelsExist(ctx, releasepr.KnownLabels)
+	return rp.forge.EnsureLabelsExist(ctx, slices.Concat(releasepr.KnownLabels, rp.labels))
 }
 
-func (rp *ReleaserPleaser) Run(ctx context.Context) error {
+// Run creates the pending releases and reconciles the release pull requests of all components. The result describes
+// the changes that were made. If an error occurs, the result contains the changes made up to that point.
+func (rp *ReleaserPleaser) Run(ctx context.Context) (*RunResult, error) {
+	result := &RunResult{DryRun: rp.dryRun, Releases: []ReleaseResult{}, PullRequests: []PullRequestResult{}}
+
 	err := rp.runOnboarding(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to onboard repository: %w", err)
+		return result, fmt.Errorf("failed to onboard repository: %w", err)
 	}
 
-	err = rp.runCreatePendingReleases(ctx)
+	releases, err := rp.runCreatePendingReleases(ctx)
+	result.Releases = append(result.Releases, releases...)
+	result.updateChanged()
 	if err != nil {
-		return fmt.Errorf("failed to create pending releases: %w", err)
+		return result, fmt.Errorf("failed to create pending releases: %w", err)
 	}
 
-	err = rp.runReconcileReleasePRWithRetries(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to reconcile release pull request: %w", err)
+	for _, component := range rp.components {
+		pr, err := rp.runReconcileReleasePRWithRetries(ctx, component)
+		if err != nil {
+			if component.Name != "" {
+				return result, fmt.Errorf("failed to reconcile release pull request for component %q: %w", component.Name, err)
+			}
+			return result, fmt.Errorf("failed to reconcile release pull request: %w", err)
+		}
+
+		if pr != nil {
+			result.PullRequests = append(result.PullRequests, *pr)
+			result.updateChanged()
+		}
 	}
 
-	return nil
+	// The release pull requests were merged right away, so they are released in this run and not reported as pull
+	// requests.
+	if merger, ok := rp.forge.(forge.PullRequestMerger); ok && merger.MergesPullRequests() && len(result.PullRequests) > 0 {
+		result.PullRequests = []PullRequestResult{}
+
+		releases, err = rp.runCreatePendingReleases(ctx)
+		result.Releases = append(result.Releases, releases...)
+		result.updateChanged()
+		if err != nil {
+			return result, fmt.Errorf("failed to create pending releases: %w", err)
+		}
+	}
+
+	return result, nil
 }
 
 func (rp *ReleaserPleaser) runOnboarding(ctx context.Context) error {
@@ -83,86 +139,348 @@ func (rp *ReleaserPleaser) runOnboarding(ctx context.Context) error {
 	return nil
 }
 
-func (rp *ReleaserPleaser) runCreatePendingReleases(ctx context.Context) error {
+func (rp *ReleaserPleaser) runCreatePendingReleases(ctx context.Context) ([]ReleaseResult, error) {
 	logger := rp.logger.With("method", "runCreatePendingReleases")
 
 	logger.InfoContext(ctx, "checking for pending releases")
 	prs, err := rp.forge.PendingReleases(ctx, releasepr.LabelReleasePending)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(prs) == 0 {
 		logger.InfoContext(ctx, "No pending releases found")
-		return nil
+		return nil, nil
 	}
 
 	logger.InfoContext(ctx, "Found pending releases", "length", len(prs))
 
+	releases := make([]ReleaseResult, 0, len(prs))
 	for _, pr := range prs {
-		err = rp.createPendingRelease(ctx, pr)
+		release, err := rp.createPendingRelease(ctx, pr)
+		if err != nil {
+			return releases, err
+		}
+
+		releases = append(releases, release)
+
+		err = rp.runAfterReleaseHooks(ctx, release)
+		if err != nil {
+			return releases, err
+		}
+
+		err = rp.runPostReleaseUpdaters(ctx, release)
 		if err != nil {
-			return err
+			return releases, err
 		}
 	}
 
-	return nil
+	return releases, nil
 }
 
-func (rp *ReleaserPleaser) createPendingRelease(ctx context.Context, pr *releasepr.ReleasePullRequest) error {
+func (rp *ReleaserPleaser) createPendingRelease(ctx context.Context, pr *releasepr.ReleasePullRequest) (ReleaseResult, error) {
 	logger := rp.logger.With(
 		"method", "createPendingRelease",
 		"pr.id", pr.ID,
 		"pr.title", pr.Title)
 
 	if pr.ReleaseCommit == nil {
-		return fmt.Errorf("pull request is missing the merge commit")
+		return ReleaseResult{}, fmt.Errorf("pull request is missing the merge commit")
 	}
 
 	logger.Info("Creating release", "commit.hash", pr.ReleaseCommit.Hash)
 
+	// The release pull request title contains the full tag name, which is built from the tag template of the component.
 	version, err := pr.Version()
 	if err != nil {
-		return err
+		return ReleaseResult{}, err
 	}
+	component, componentVersion := rp.componentForTag(version)
 
-	changelogText, err := pr.ChangelogText()
+	changelogText, err := pr.ReleaseText()
 	if err != nil {
-		return err
+		return ReleaseResult{}, err
+	}
+
+	releaseCommit := *pr.ReleaseCommit
+	if strategy, ok := rp.versioning.(versioning.DateStrategy); ok && strategy.Outdated(componentVersion) {
+		redated, commit, err := rp.redatePendingRelease(ctx, logger, strategy, component, releaseCommit, componentVersion)
+		if err != nil {
+			return ReleaseResult{}, err
+		}
+		if redated != componentVersion {
+			changelogText = replaceVersion(changelogText, componentVersion, redated)
+			componentVersion, version, releaseCommit = redated, component.TagTemplate.Tag(redated), commit
+		}
 	}
 
-	// TODO: Check if version should be marked latest
+	prerelease := rp.versioning.IsPrerelease(componentVersion)
+	latest, err := rp.isLatestRelease(ctx, component, componentVersion, prerelease)
+	if err != nil {
+		return ReleaseResult{}, err
+	}
 
-	logger.DebugContext(ctx, "Creating release on forge")
-	err = rp.forge.CreateRelease(ctx, *pr.ReleaseCommit, version, changelogText, rp.versioning.IsPrerelease(version), true)
+	logger.DebugContext(ctx, "Creating release on forge", "release.prerelease", prerelease, "release.latest", latest)
+	if len(component.Assets) > 0 {
+		err = rp.createReleaseWithAssets(ctx, component, releaseCommit, version, changelogText, prerelease, latest)
+	} else {
+		err = rp.forge.CreateRelease(ctx, releaseCommit, version, changelogText, prerelease, latest)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create release on forge: %w", err)
+		return ReleaseResult{}, fmt.Errorf("failed to create release on forge: %w", err)
 	}
 	logger.DebugContext(ctx, "created release", "release.title", version, "release.url", rp.forge.ReleaseURL(version))
 
 	logger.DebugContext(ctx, "updating pr labels")
 	err = rp.forge.SetPullRequestLabels(ctx, pr, []releasepr.Label{releasepr.LabelReleasePending}, []releasepr.Label{releasepr.LabelReleaseTagged})
 	if err != nil {
-		return err
+		return ReleaseResult{}, err
 	}
 	logger.DebugContext(ctx, "updated pr labels")
 
 	logger.InfoContext(ctx, "Created release", "release.title", version, "release.url", rp.forge.ReleaseURL(version))
 
+	return ReleaseResult{
+		Component:  component.Name,
+		Version:    componentVersion,
+		Tag:        version,
+		Commit:     releaseCommit.Hash,
+		URL:        rp.forge.ReleaseURL(version),
+		Prerelease: prerelease,
+		Latest:     latest,
+	}, nil
+}
+
+// runAfterReleaseHooks runs the AfterReleaseHooks of the component of the release. In dry-run mode the hooks are only
+// logged, as the release was not created.
+// redatePendingRelease recalculates the version of a release pull request that was last updated in an earlier period
+// than the merge, e.g. in the previous month with CalVer. The version is replaced in the lines that the release commit
+// changed, and the changes are pushed to the target branch, so that the release is created with the current date.
+//
+// It returns the unchanged version if the release commit is not the head of the target branch anymore or the changes
+// can not be pushed, in that case the release is created with the outdated version.
+func (rp *ReleaserPleaser) redatePendingRelease(ctx context.Context, logger *slog.Logger, strategy versioning.DateStrategy, component Component, releaseCommit git.Commit, version string) (string, git.Commit, error) {
+	repo, err := rp.cloneRepository(ctx, logger)
+	if err != nil {
+		return "", git.Commit{}, err
+	}
+
+	releases, err := rp.latestTags(ctx, component, repo)
+	if err != nil {
+		return "", git.Commit{}, err
+	}
+
+	redated, err := strategy.Redate(releases, version)
+	if err != nil {
+		return "", git.Commit{}, fmt.Errorf("failed to recalculate outdated version %s: %w", version, err)
+	}
+	logger = logger.With("release.version", version, "release.redated", redated)
+
+	head, err := repo.Head(ctx)
+	if err != nil {
+		return "", git.Commit{}, err
+	}
+	if head != releaseCommit.Hash {
+		logger.WarnContext(ctx, "version of the release pull request is outdated, but the target branch has new commits, creating the release with the outdated version", "branch.name", rp.targetBranch)
+		return version, releaseCommit, nil
+	}
+
+	if rp.dryRun {
+		logger.InfoContext(ctx, "would commit recalculated version", "branch.name", rp.targetBranch)
+		return version, releaseCommit, nil
+	}
+
+	files, err := repo.ChangedFiles(ctx, releaseCommit.Hash)
+	if err != nil {
+		return "", git.Commit{}, err
+	}
+
+	for _, file := range files {
+		content, err := repo.ReadFile(ctx, file)
+		if err != nil {
+			return "", git.Commit{}, err
+		}
+		if content == nil {
+			// Removed by the release commit
+			continue
+		}
+
+		before, err := repo.ReadFileBefore(ctx, releaseCommit.Hash, file)
+		if err != nil {
+			return "", git.Commit{}, err
+		}
+
+		err = repo.UpdateFile(ctx, file, false, func(content string) (string, error) {
+			return replaceVersionInAddedLines(string(before), content, version, redated), nil
+		})
+		if err != nil {
+			return "", git.Commit{}, err
+		}
+	}
+
+	author, err := rp.forge.CommitAuthor(ctx)
+	if err != nil {
+		return "", git.Commit{}, fmt.Errorf("failed to get commit author: %w", err)
+	}
+
+	message := fmt.Sprintf("chore(%s): release %s", rp.targetBranch, component.TagTemplate.Tag(redated))
+	commit, err := repo.Commit(ctx, message, author)
+	if errors.Is(err, git.ErrNoChanges) {
+		// The version is not part of any file, only the tag changes
+		return redated, releaseCommit, nil
+	}
+	if err != nil {
+		return "", git.Commit{}, err
+	}
+
+	if err = repo.Push(ctx, rp.targetBranch); err != nil {
+		logger.WarnContext(ctx, "failed to push recalculated version, creating the release with the outdated version", "branch.name", rp.targetBranch, "error", err)
+		return version, releaseCommit, nil
+	}
+
+	logger.InfoContext(ctx, "pushed recalculated version", "commit.hash", commit.Hash, "branch.name", rp.targetBranch)
+
+	return redated, commit, nil
+}
+
+// replaceVersionInAddedLines replaces the version in all lines of content that are not in before. Existing lines are
+// left alone, so that older entries in a changelog are not changed.
+func replaceVersionInAddedLines(before, content, version, replacement string) string {
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(before, "\n") {
+		existing[line] = true
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !existing[line] {
+			lines[i] = replaceVersion(line, version, replacement)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// replaceVersion replaces all occurrences of the version in the text. Longer versions that start with the version, e.g.
+// 1.2.30 or 1.2.3-rc.1 for 1.2.3, are not replaced.
+func replaceVersion(text, version, replacement string) string {
+	pattern := regexp.MustCompile(`(^|[^0-9.])` + regexp.QuoteMeta(version) + `($|[^0-9.-]|\.$|\.[^0-9])`)
+
+	// The pattern consumes the characters around the version, so versions that are separated by a single character are
+	// only found by a second pass.
+	for range 2 {
+		text = pattern.ReplaceAllString(text, "${1}"+replacement+"${2}")
+	}
+
+	return text
+}
+
+func (rp *ReleaserPleaser) runAfterReleaseHooks(ctx context.Context, release ReleaseResult) error {
+	component, _ := rp.componentForTag(release.Tag)
+	if len(component.AfterReleaseHooks) == 0 {
+		return nil
+	}
+
+	env := map[string]string{
+		hook.EnvComponent:  release.Component,
+		hook.EnvVersion:    release.Version,
+		hook.EnvTag:        release.Tag,
+		hook.EnvCommit:     release.Commit,
+		hook.EnvReleaseURL: release.URL,
+		hook.EnvPrerelease: strconv.FormatBool(release.Prerelease),
+	}
+
+	for _, command := range component.AfterReleaseHooks {
+		if rp.dryRun {
+			rp.logger.InfoContext(ctx, "would run hook", "hook.command", command, "release.title", release.Tag)
+			continue
+		}
+
+		err := hook.Run(ctx, rp.logger, command, "", env)
+		if err != nil {
+			return fmt.Errorf("failed to run after-release hook: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// createReleaseWithAssets creates the release with the assets of the component and a checksums file of them.
+func (rp *ReleaserPleaser) createReleaseWithAssets(ctx context.Context, component Component, commit git.Commit, title, changelog string, prerelease, latest bool) error {
+	uploader, ok := rp.forge.(forge.AssetUploader)
+	if !ok {
+		return forge.ErrReleaseAssetsUnsupported
+	}
+
+	releaseAssets, err := assets.Find(component.Assets)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "releaser-pleaser-assets-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	releaseAssets, err = assets.WithChecksums(dir, releaseAssets)
+	if err != nil {
+		return err
+	}
+
+	rp.logger.DebugContext(ctx, "uploading release assets", "release.title", title, "assets.length", len(releaseAssets))
+	return uploader.CreateReleaseWithAssets(ctx, commit, title, changelog, prerelease, latest, releaseAssets)
+}
+
+// isLatestRelease checks if the version is at least as high as the highest stable release of the component. Releases
+// of older lines, eg. v1.9.3 after v2.1.0, and pre-releases are not the latest release.
+func (rp *ReleaserPleaser) isLatestRelease(ctx context.Context, component Component, version string, prerelease bool) (bool, error) {
+	if prerelease {
+		return false, nil
+	}
+
+	releases, err := rp.forge.LatestTags(ctx, component.releaseTags(rp.versioning))
+	if err != nil {
+		return false, err
+	}
+
+	stable := component.tagVersions(releases).Stable
+	if stable == nil {
+		return true, nil
+	}
+
+	return rp.versioning.Compare(version, stable.Name) >= 0, nil
+}
+
+// componentForTag returns the first component whose tag template matches the tag, together with the version in the
+// tag. If no component matches, the tag is returned as the version of an empty component.
+func (rp *ReleaserPleaser) componentForTag(tag string) (Component, string) {
+	for _, component := range rp.components {
+		if version, ok := component.releaseTags(rp.versioning).Version(tag); ok {
+			return component, version
+		}
+	}
+
+	return Component{}, tag
+}
+
 // runReconcileReleasePRWithRetries retries runReconcileReleasePR up to PullRequestConflictAttempts times, but only
 // when a ErrorPullRequestConflict was encountered.
-func (rp *ReleaserPleaser) runReconcileReleasePRWithRetries(ctx context.Context) error {
+func (rp *ReleaserPleaser) runReconcileReleasePRWithRetries(ctx context.Context, component Component) (*PullRequestResult, error) {
 	logger := rp.logger.With("method", "runReconcileReleasePRWithRetries", "totalAttempts", PullRequestConflictAttempts)
-	var err error
+	if component.Name != "" {
+		logger = logger.With("component", component.Name)
+	}
+	var (
+		result *PullRequestResult
+		err    error
+	)
 
 	for i := range PullRequestConflictAttempts {
 		logger := logger.With("attempt", i+1)
 		logger.DebugContext(ctx, "attempting runReconcileReleasePR")
 
-		err = rp.runReconcileReleasePR(ctx)
+		result, err = rp.runReconcileReleasePR(ctx, component)
 		if err != nil {
 			if errors.Is(err, ErrorPullRequestConflict) {
 				logger.WarnContext(ctx, "detected conflict while updating pull request description, retrying")
@@ -176,20 +494,25 @@ func (rp *ReleaserPleaser) runReconcileReleasePRWithRetries(ctx context.Context)
 	}
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return result, nil
 }
 
-func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
+// runReconcileReleasePR creates, updates or closes the release pull request of the component. It returns nil if there
+// is no release pull request and no releasable commits.
+func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context, component Component) (*PullRequestResult, error) {
 	logger := rp.logger.With("method", "runReconcileReleasePR")
+	if component.Name != "" {
+		logger = logger.With("component", component.Name)
+	}
 
-	rpBranch := fmt.Sprintf(PullRequestBranchFormat, rp.targetBranch)
+	rpBranch := component.pullRequestBranch(rp.targetBranch)
 
 	pr, err := rp.forge.PullRequestForBranch(ctx, rpBranch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var releaseOverrides releasepr.ReleaseOverrides
@@ -200,13 +523,23 @@ func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
 
 		releaseOverrides, err = pr.GetOverrides()
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	// Filtering the commits by path requires the content of the commits, and finding the tags of a maintenance branch
+	// requires the history of the branch. We get both from the clone.
+	var repo *git.Repository
+	if component.cleanPath() != "" || rp.versionLine != "" {
+		repo, err = rp.cloneRepository(ctx, logger)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	releases, err := rp.forge.LatestTags(ctx)
+	releases, err := rp.latestTags(ctx, component, repo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if releases.Latest != nil {
@@ -222,90 +555,129 @@ func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
 	// For prereleases, we want to consider all changes...
 	// - since the last stable release for the version
 	// - since the latest release (stable or prerelease) for the changelog
-	analyzedCommitsForVersioning, err := rp.analyzedCommitsSince(ctx, releases.Stable)
+	analyzedCommitsForVersioning, err := rp.analyzedCommitsSince(ctx, component, repo, releases.Stable)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	releaseVersions := component.tagVersions(releases)
+
+	versionBump, err := rp.versioning.Bump(releaseVersions, versioning.BumpFromCommits(analyzedCommitsForVersioning, rp.commitTypes))
+	if err != nil {
+		return nil, err
+	}
+	forced, err := forcedVersion(logger, rp.versioning, component, releaseVersions, releaseOverrides, analyzedCommitsForVersioning)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(analyzedCommitsForVersioning) == 0 {
+	if versionBump == versioning.UnknownVersion && forced == "" {
 		if pr != nil {
 			logger.InfoContext(ctx, "closing existing pull requests, no commits available", "pr.id", pr.ID, "pr.title", pr.Title)
 			err = rp.forge.ClosePullRequest(ctx, pr)
 			if err != nil {
-				return err
+				return nil, err
 			}
-		} else {
-			logger.InfoContext(ctx, "No commits available for release")
+
+			return &PullRequestResult{
+				Component: component.Name,
+				ID:        pr.ID,
+				URL:       rp.forge.PullRequestURL(pr.ID),
+				Status:    PullRequestClosed,
+			}, nil
 		}
 
-		return nil
+		logger.InfoContext(ctx, "No commits available for release")
+		return nil, nil
 	}
 
-	versionBump := versioning.BumpFromCommits(analyzedCommitsForVersioning)
-	// TODO: Set version in release pr
-	nextVersion, err := rp.versioning.NextVersion(releases, versionBump, releaseOverrides.NextVersionType)
-	if err != nil {
-		return err
+	nextVersion := forced
+	if nextVersion == "" {
+		nextVersion, err = rp.versioning.NextVersion(releaseVersions, versionBump, releaseOverrides.NextVersionType)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logger.InfoContext(ctx, "using forced version", "version", nextVersion)
+	}
+
+	if !rp.versionLine.Contains(nextVersion) {
+		if forced != "" {
+			return nil, fmt.Errorf("forced version %s is not part of the version line %s of branch %s", nextVersion, rp.versionLine, rp.targetBranch)
+		}
+		return nil, fmt.Errorf("next version %s is not part of the version line %s of branch %s, the commits require a %s version bump", nextVersion, rp.versionLine, rp.targetBranch, versionBump)
 	}
-	logger.InfoContext(ctx, "next version", "version", nextVersion)
+	nextTag := component.TagTemplate.Tag(nextVersion)
+	logger.InfoContext(ctx, "next version", "version", nextVersion, "tag", nextTag)
 
 	changelogBaseTag := releases.Stable
 	analyzedCommitsForChangelog := analyzedCommitsForVersioning
 	if releaseOverrides.NextVersionType.IsPrerelease() && releases.Latest != releases.Stable {
 		changelogBaseTag = releases.Latest
-		analyzedCommitsForChangelog, err = rp.analyzedCommitsSince(ctx, releases.Latest)
+		analyzedCommitsForChangelog, err = rp.analyzedCommitsSince(ctx, component, repo, releases.Latest)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	var compareURL string
 	if changelogBaseTag != nil {
-		compareURL = rp.forge.CompareURL(changelogBaseTag.Name, nextVersion)
+		compareURL = rp.forge.CompareURL(changelogBaseTag.Name, nextTag)
 	}
 
-	logger.DebugContext(ctx, "cloning repository", "clone.url", rp.forge.CloneURL())
-	repo, err := git.CloneRepo(ctx, logger, rp.forge.CloneURL(), rp.targetBranch, rp.forge.GitAuth())
-	if err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+	if repo == nil {
+		repo, err = rp.cloneRepository(ctx, logger)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if err = repo.DeleteBranch(ctx, rpBranch); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = repo.Checkout(ctx, rpBranch); err != nil {
-		return err
+		return nil, err
 	}
 
-	changelogData := changelog.New(commitparser.ByType(analyzedCommitsForChangelog), nextVersion, rp.forge.ReleaseURL(nextVersion), compareURL, releaseOverrides.Prefix, releaseOverrides.Suffix)
+	changelogData := changelog.New(commitparser.ByType(analyzedCommitsForChangelog), rp.commitTypes, nextTag, rp.forge.ReleaseURL(nextTag), compareURL, releaseOverrides.Prefix, releaseOverrides.Suffix)
+	changelogData.ReleaseDate = time.Now().UTC()
+	changelogData.Bump = versionBump.String()
+	if changelogBaseTag != nil {
+		changelogData.PreviousVersion = changelogBaseTag.Name
+	}
 
-	changelogEntry, err := changelog.Entry(logger, changelog.DefaultTemplate(), changelogData, changelog.Formatting{})
+	changelogEntry, err := changelog.Entry(logger, changelog.OrDefault(rp.templates.Changelog), changelogData, changelog.Formatting{})
 	if err != nil {
-		return fmt.Errorf("failed to build changelog entry: %w", err)
+		return nil, fmt.Errorf("failed to build changelog entry: %w", err)
 	}
 
 	// Info for updaters
 	info := updater.ReleaseInfo{Version: nextVersion, ChangelogEntry: changelogEntry}
 
-	for _, u := range rp.updaters {
-		for _, file := range u.Files() {
-			err = repo.UpdateFile(ctx, file, u.CreateNewFiles(), u.Update(info))
-			if err != nil {
-				return fmt.Errorf("failed to run updater %T: %w", u, err)
-			}
+	err = runUpdaters(ctx, repo, component, component.Updaters, func(u updater.Updater) func(string) (string, error) {
+		return u.Update(info)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(component.BeforeCommitHooks) > 0 {
+		err = runBeforeCommitHooks(ctx, logger, repo, component, nextVersion, nextTag)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	releaseCommitAuthor, err := rp.forge.CommitAuthor(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get commit author: %w", err)
+		return nil, fmt.Errorf("failed to get commit author: %w", err)
 	}
 
-	releaseCommitMessage := fmt.Sprintf("chore(%s): release %s", rp.targetBranch, nextVersion)
+	releaseCommitMessage := fmt.Sprintf("chore(%s): release %s", rp.targetBranch, nextTag)
 	releaseCommit, err := repo.Commit(ctx, releaseCommitMessage, releaseCommitAuthor)
 	if err != nil {
-		return fmt.Errorf("failed to commit changes: %w", err)
+		return nil, fmt.Errorf("failed to commit changes: %w", err)
 	}
 
 	logger.InfoContext(ctx, "created release commit", "commit.hash", releaseCommit.Hash, "commit.message", releaseCommit.Message, "commit.author", releaseCommitAuthor)
@@ -313,13 +685,24 @@ func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
 	// Check if anything changed in comparison to the remote branch (if exists)
 	newReleasePRChanges, err := repo.HasChangesWithRemote(ctx, rp.targetBranch, rpBranch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if newReleasePRChanges {
+	if rp.dryRun {
+		diff, err := repo.DiffWithRemote(ctx, rp.targetBranch, rpBranch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get diff of release commit: %w", err)
+		}
+
+		logger.InfoContext(ctx, "would push branch", "branch.name", rpBranch, "changes", newReleasePRChanges)
+		_, err = fmt.Fprint(rp.output, diff)
+		if err != nil {
+			return nil, err
+		}
+	} else if newReleasePRChanges {
 		err = repo.ForcePush(ctx, rpBranch)
 		if err != nil {
-			return fmt.Errorf("failed to push branch: %w", err)
+			return nil, fmt.Errorf("failed to push branch: %w", err)
 		}
 
 		logger.InfoContext(ctx, "pushed branch", "commit.hash", releaseCommit.Hash, "branch.name", rpBranch)
@@ -329,21 +712,33 @@ func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
 
 	// We do not need the version title here. In the pull request the version is available from the title, and in the
 	// release on the Forge its usually in a heading somewhere above the text.
-	changelogEntryPullRequest, err := changelog.Entry(logger, changelog.DefaultTemplate(), changelogData, changelog.Formatting{HideVersionTitle: true})
+	changelogEntryPullRequest, err := changelog.Entry(logger, changelog.OrDefault(rp.templates.PullRequest), changelogData, changelog.Formatting{HideVersionTitle: true})
 	if err != nil {
-		return fmt.Errorf("failed to build pull request changelog entry: %w", err)
+		return nil, fmt.Errorf("failed to build pull request changelog entry: %w", err)
+	}
+
+	// The release notes are stored in the pull request description, so they are available when the release is created
+	// after the pull request was merged.
+	var releaseNotes string
+	if rp.templates.Release != nil {
+		releaseNotes, err = changelog.Entry(logger, rp.templates.Release, changelogData, changelog.Formatting{HideVersionTitle: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build release notes: %w", err)
+		}
 	}
 
 	// Open/Update PR
+	status := PullRequestCreated
 	if pr == nil {
-		pr, err = releasepr.NewReleasePullRequest(rpBranch, rp.targetBranch, nextVersion, changelogEntryPullRequest)
+		pr, err = releasepr.NewReleasePullRequest(rpBranch, rp.targetBranch, nextTag, changelogEntryPullRequest, releaseNotes)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		pr.Labels = append(pr.Labels, rp.labels...)
 
 		err = rp.forge.CreatePullRequest(ctx, pr)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		logger.InfoContext(ctx, "opened pull request", "pr.title", pr.Title, "pr.id", pr.ID, "pr.url", rp.forge.PullRequestURL(pr.ID))
 	} else {
@@ -355,37 +750,276 @@ func (rp *ReleaserPleaser) runReconcileReleasePR(ctx context.Context) error {
 		logger.DebugContext(ctx, "checking for conflict in pr description", "pr.id", pr.ID)
 		recheckPR, err := rp.forge.PullRequestByID(ctx, pr)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if recheckPR == nil {
-			return fmt.Errorf("PR was deleted while releaser-pleaser was running")
+			return nil, fmt.Errorf("PR was deleted while releaser-pleaser was running")
 		}
 		if recheckPR.Description != pr.Description {
-			return ErrorPullRequestConflict
+			return nil, ErrorPullRequestConflict
 		}
 
-		pr.SetTitle(rp.targetBranch, nextVersion)
+		previousTitle, previousDescription := pr.Title, pr.Description
+		pr.SetTitle(rp.targetBranch, nextTag)
 
 		overrides, err := pr.GetOverrides()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		err = pr.SetDescription(changelogEntryPullRequest, overrides)
+		err = pr.SetDescription(changelogEntryPullRequest, releaseNotes, overrides)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		status = PullRequestUnchanged
+		if newReleasePRChanges || pr.Title != previousTitle || pr.Description != previousDescription {
+			status = PullRequestUpdated
 		}
 
 		err = rp.forge.UpdatePullRequest(ctx, pr)
 		if err != nil {
-			return err
+			return nil, err
 		}
+
+		// Labels that were added to the configuration after the pull request was opened
+		var missingLabels []releasepr.Label
+		for _, label := range rp.labels {
+			if !slices.ContainsFunc(pr.Labels, func(l releasepr.Label) bool { return l.Name == label.Name }) {
+				missingLabels = append(missingLabels, label)
+			}
+		}
+		if len(missingLabels) > 0 {
+			logger.DebugContext(ctx, "adding missing labels to pr", "pr.id", pr.ID)
+			err = rp.forge.SetPullRequestLabels(ctx, pr, nil, missingLabels)
+			if err != nil {
+				return nil, err
+			}
+			pr.Labels = append(pr.Labels, missingLabels...)
+			status = PullRequestUpdated
+		}
+
 		logger.InfoContext(ctx, "updated pull request", "pr.title", pr.Title, "pr.id", pr.ID, "pr.url", rp.forge.PullRequestURL(pr.ID))
 	}
 
+	return &PullRequestResult{
+		Component: component.Name,
+		ID:        pr.ID,
+		URL:       rp.forge.PullRequestURL(pr.ID),
+		Version:   nextVersion,
+		Tag:       nextTag,
+		Status:    status,
+	}, nil
+}
+
+// runPostReleaseUpdaters commits the changes of the updaters that implement updater.PostReleaseUpdater, e.g. the next
+// snapshot version, directly to the target branch. If the push is rejected, only a warning is logged. In dry-run mode
+// nothing is committed.
+func (rp *ReleaserPleaser) runPostReleaseUpdaters(ctx context.Context, release ReleaseResult) error {
+	component, _ := rp.componentForTag(release.Tag)
+
+	updaters := slices.DeleteFunc(slices.Clone(component.Updaters), func(u updater.Updater) bool {
+		_, ok := u.(updater.PostReleaseUpdater)
+		return !ok
+	})
+	if len(updaters) == 0 {
+		return nil
+	}
+
+	logger := rp.logger.With("method", "runPostReleaseUpdaters", "release.title", release.Tag)
+	if rp.dryRun {
+		logger.InfoContext(ctx, "would commit post-release changes", "branch.name", rp.targetBranch)
+		return nil
+	}
+
+	repo, err := rp.cloneRepository(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	info := updater.ReleaseInfo{Version: release.Version}
+	err = runUpdaters(ctx, repo, component, updaters, func(u updater.Updater) func(string) (string, error) {
+		return u.(updater.PostReleaseUpdater).UpdateAfterRelease(info)
+	})
+	if err != nil {
+		return err
+	}
+
+	author, err := rp.forge.CommitAuthor(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get commit author: %w", err)
+	}
+
+	message := fmt.Sprintf("chore(%s): prepare next development iteration after %s", rp.targetBranch, release.Tag)
+	commit, err := repo.Commit(ctx, message, author)
+	if errors.Is(err, git.ErrNoChanges) {
+		logger.InfoContext(ctx, "post-release updaters did not change any files")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The release was already created, failing the run would not help as the next run does not know about it anymore.
+	// This usually happens if the target branch is protected or was changed in the meantime.
+	if err = repo.Push(ctx, rp.targetBranch); err != nil {
+		logger.WarnContext(ctx, "failed to push post-release commit, commit the changes to the target branch manually", "branch.name", rp.targetBranch, "commit.message", message, "error", err)
+		return nil
+	}
+
+	logger.InfoContext(ctx, "pushed post-release commit", "commit.hash", commit.Hash, "branch.name", rp.targetBranch)
+
+	return nil
+}
+
+// runUpdaters applies the result of update for every updater to its files in the clone. The files are relative to the
+// component, updaters that implement updater.FileFinder can add files that they found in the clone.
+func runUpdaters(ctx context.Context, repo *git.Repository, component Component, updaters []updater.Updater, update func(updater.Updater) func(string) (string, error)) error {
+	repoDir, err := repo.Dir()
+	if err != nil {
+		return err
+	}
+
+	for _, u := range updaters {
+		files := u.Files()
+		if finder, ok := u.(updater.FileFinder); ok {
+			found, err := finder.FindFiles(os.DirFS(filepath.Join(repoDir, component.cleanPath())))
+			if err != nil {
+				return fmt.Errorf("failed to find files for updater %T: %w", u, err)
+			}
+			files = append(files, found...)
+		}
+
+		for _, file := range files {
+			err = repo.UpdateFile(ctx, component.filePath(file), u.CreateNewFiles(), update(u))
+			if err != nil {
+				return fmt.Errorf("failed to run updater %T: %w", u, err)
+			}
+		}
+	}
+
 	return nil
 }
 
-func (rp *ReleaserPleaser) analyzedCommitsSince(ctx context.Context, since *git.Tag) ([]commitparser.AnalyzedCommit, error) {
+// runBeforeCommitHooks runs the BeforeCommitHooks of the component in the clone and stages all changes they made.
+func runBeforeCommitHooks(ctx context.Context, logger *slog.Logger, repo *git.Repository, component Component, version, tag string) error {
+	dir, err := repo.Dir()
+	if err != nil {
+		return err
+	}
+
+	env := map[string]string{
+		hook.EnvComponent: component.Name,
+		hook.EnvVersion:   version,
+		hook.EnvTag:       tag,
+	}
+
+	for _, command := range component.BeforeCommitHooks {
+		err = hook.Run(ctx, logger, command, filepath.Join(dir, component.cleanPath()), env)
+		if err != nil {
+			return fmt.Errorf("failed to run before-commit hook: %w", err)
+		}
+	}
+
+	return repo.AddAll(ctx)
+}
+
+func (rp *ReleaserPleaser) cloneRepository(ctx context.Context, logger *slog.Logger) (*git.Repository, error) {
+	logger.DebugContext(ctx, "cloning repository", "clone.url", rp.forge.CloneURL())
+	repo, err := git.CloneRepo(ctx, logger, rp.forge.CloneURL(), rp.targetBranch, rp.forge.GitAuth())
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	return repo, nil
+}
+
+// latestTags returns the latest releases of the component. On maintenance branches only the tags that are reachable
+// from the branch are considered, which requires the clone in repo. Releases of newer lines on other branches are
+// ignored.
+func (rp *ReleaserPleaser) latestTags(ctx context.Context, component Component, repo *git.Repository) (git.Releases, error) {
+	if rp.versionLine == "" {
+		return rp.forge.LatestTags(ctx, component.releaseTags(rp.versioning))
+	}
+
+	return repo.LatestReachableTags(ctx, component.releaseTags(rp.versioning))
+}
+
+// forcedVersion returns the version that replaces the version calculated by the strategy, or an empty string if no
+// version is forced. The override from the release pull request takes precedence over the highest `Release-As` footer
+// of the commits. The versions may also be written as tags of the component, eg. `v2.0.0`.
+//
+// The override must be greater than the latest release, which is passed as a version in releases. Footers that are not
+// greater were already released, eg. as a pre-release, and are ignored with a warning. A forced version must be a
+// pre-release if the release pull request requests one, the suffix can not be added to a forced version.
+func forcedVersion(logger *slog.Logger, strategy versioning.Strategy, component Component, releases git.Releases, overrides releasepr.ReleaseOverrides, commits []commitparser.AnalyzedCommit) (string, error) {
+	releaseTags := component.releaseTags(strategy)
+	isReleased := func(version string) bool {
+		return releases.Latest != nil && strategy.Compare(version, releases.Latest.Name) <= 0
+	}
+	normalize := func(version string) (string, error) {
+		if v, ok := releaseTags.Version(version); ok {
+			return v, nil
+		}
+		if !strategy.IsValid(version) {
+			return "", fmt.Errorf("forced version %q is not a valid version", version)
+		}
+
+		return version, nil
+	}
+	checkPrerelease := func(version string) (string, error) {
+		if overrides.NextVersionType.IsPrerelease() && !strategy.IsPrerelease(version) {
+			return "", fmt.Errorf("forced version %s is not a pre-release, but a %s pre-release was requested, force a pre-release version instead", version, overrides.NextVersionType)
+		}
+
+		return version, nil
+	}
+
+	if overrides.Version != "" {
+		version, err := normalize(overrides.Version)
+		if err != nil {
+			return "", err
+		}
+		if isReleased(version) {
+			return "", fmt.Errorf("forced version %s must be greater than the latest release %s", version, releases.Latest.Name)
+		}
+
+		return checkPrerelease(version)
+	}
+
+	var version string
+	for _, commit := range commits {
+		if commit.ReleaseAs == "" {
+			continue
+		}
+
+		candidate, err := normalize(commit.ReleaseAs)
+		if err != nil {
+			return "", fmt.Errorf("invalid Release-As footer in commit %s: %w", commit.Hash, err)
+		}
+
+		if isReleased(candidate) {
+			// The footer of a pre-release stays in the commits until the next stable release
+			if candidate != releases.Latest.Name {
+				logger.Warn("ignoring Release-As footer, the version is not greater than the latest release", "commit.hash", commit.Hash, "version", candidate, "release.latest", releases.Latest.Name)
+			}
+			continue
+		}
+
+		if version == "" || strategy.Compare(candidate, version) > 0 {
+			version = candidate
+		}
+	}
+
+	if version == "" {
+		return "", nil
+	}
+
+	return checkPrerelease(version)
+}
+
+// analyzedCommitsSince returns the analyzed commits after the tag. If the component has a path, repo is used to
+// filter out commits that do not touch any files of the component.
+func (rp *ReleaserPleaser) analyzedCommitsSince(ctx context.Context, component Component, repo *git.Repository, since *git.Tag) ([]commitparser.AnalyzedCommit, error) {
 	logger := rp.logger.With("method", "analyzedCommitsSince")
 
 	if since != nil {
@@ -397,6 +1031,13 @@ func (rp *ReleaserPleaser) analyzedCommitsSince(ctx context.Context, since *git.
 		return nil, err
 	}
 
+	if component.cleanPath() != "" {
+		commits, err = filterCommitsByPath(ctx, component, repo, commits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	commits, err = parsePRBodyForCommitOverrides(commits)
 	if err != nil {
 		return nil, err
@@ -413,3 +1054,20 @@ func (rp *ReleaserPleaser) analyzedCommitsSince(ctx context.Context, since *git.
 
 	return analyzedCommits, nil
 }
+
+func filterCommitsByPath(ctx context.Context, component Component, repo *git.Repository, commits []git.Commit) ([]git.Commit, error) {
+	result := make([]git.Commit, 0, len(commits))
+
+	for _, commit := range commits {
+		files, err := repo.ChangedFiles(ctx, commit.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get changed files of commit: %w", err)
+		}
+
+		if slices.ContainsFunc(files, component.containsFile) {
+			result = append(result, commit)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,1170 @@
+package rp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apricote/releaser-pleaser/internal/changelog"
+	"github.com/apricote/releaser-pleaser/internal/commitparser"
+	"github.com/apricote/releaser-pleaser/internal/commitparser/conventionalcommits"
+	"github.com/apricote/releaser-pleaser/internal/forge"
+	"github.com/apricote/releaser-pleaser/internal/forge/dryrun"
+	"github.com/apricote/releaser-pleaser/internal/forge/fake"
+	"github.com/apricote/releaser-pleaser/internal/forge/plaingit"
+	"github.com/apricote/releaser-pleaser/internal/git"
+	"github.com/apricote/releaser-pleaser/internal/releasepr"
+	"github.com/apricote/releaser-pleaser/internal/updater"
+	"github.com/apricote/releaser-pleaser/internal/versioning"
+)
+
+var testRemoteID atomic.Int64
+
+func newTestForge(t *testing.T) *fake.Forge {
+	t.Helper()
+
+	f, err := fake.New(slog.New(slog.DiscardHandler), &fake.Options{
+		Options: forge.Options{
+			Repository: fmt.Sprintf("test-%d", testRemoteID.Add(1)),
+			BaseBranch: "main",
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(f.Remote.Close)
+
+	return f
+}
+
+func newTestTagTemplate(t *testing.T, text, component string) versioning.TagTemplate {
+	t.Helper()
+
+	template, err := versioning.NewTagTemplate(text, component)
+	require.NoError(t, err)
+
+	return template
+}
+
+func newTestReleaserPleaser(t *testing.T, f forge.Forge) *ReleaserPleaser {
+	t.Helper()
+
+	logger := slog.New(slog.DiscardHandler)
+
+	return New(
+		f,
+		logger,
+		"main",
+		conventionalcommits.NewParser(logger, commitparser.DefaultCommitTypes),
+		commitparser.DefaultCommitTypes,
+		versioning.SemVer,
+		[]Component{{
+			TagTemplate: newTestTagTemplate(t, versioning.DefaultSemVerTagTemplate, ""),
+			Updaters:    []updater.Updater{updater.Changelog()},
+		}},
+		nil,
+		changelog.Templates{},
+		false,
+		io.Discard,
+	)
+}
+
+// runReleaserPleaser runs rp and fails the test on errors.
+func runReleaserPleaser(t *testing.T, rp *ReleaserPleaser) *RunResult {
+	t.Helper()
+
+	result, err := rp.Run(context.Background())
+	require.NoError(t, err)
+
+	return result
+}
+
+func commit(t *testing.T, f *fake.Forge, message string) string {
+	t.Helper()
+
+	hash, err := f.Remote.Commit("main", message, nil)
+	require.NoError(t, err)
+
+	return hash
+}
+
+func tagHead(t *testing.T, f *fake.Forge, name string) {
+	t.Helper()
+
+	head, err := f.Remote.Head("main")
+	require.NoError(t, err)
+	require.NoError(t, f.Remote.Tag(name, head))
+}
+
+func TestReleaserPleaser_Run(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup prepares the forge, it can call run to simulate previous runs of releaser-pleaser.
+		setup func(t *testing.T, f *fake.Forge, run func())
+		// dryRun runs the checked run with --dry-run. The component has a failing after-release hook and a snapshot
+		// updater, so that the run fails or changes the branch if these are not skipped.
+		dryRun     bool
+		wantOutput []string
+		wantErr    assert.ErrorAssertionFunc
+		check      func(t *testing.T, f *fake.Forge)
+	}{
+		{
+			name:    "no commits",
+			setup:   func(*testing.T, *fake.Forge, func()) {},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				assert.Empty(t, f.PullRequests)
+				assert.Empty(t, f.Releases)
+				assert.Contains(t, f.Labels, releasepr.LabelReleasePending.Name)
+			},
+		},
+		{
+			name: "opens release pull request",
+			setup: func(t *testing.T, f *fake.Forge, _ func()) {
+				commit(t, f, "feat: add foo")
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v0.1.0", prs[0].Title)
+				assert.Equal(t, "releaser-pleaser--branches--main", prs[0].Head)
+				assert.Equal(t, []releasepr.Label{releasepr.LabelReleasePending}, prs[0].Labels)
+				assert.Contains(t, prs[0].Description, "### Features")
+				assert.Contains(t, prs[0].Description, "add foo")
+
+				changelog, err := f.Remote.File(prs[0].Head, "CHANGELOG.md")
+				require.NoError(t, err)
+				assert.Contains(t, string(changelog), "## [v0.1.0]")
+			},
+		},
+		{
+			name: "updates release pull request",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				commit(t, f, "feat: add foo")
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, int64(1), prs[0].ID)
+				assert.Equal(t, "chore(main): release v1.1.0", prs[0].Title)
+				assert.Contains(t, prs[0].Description, "add foo")
+				assert.Contains(t, prs[0].Description, "bar")
+			},
+		},
+		{
+			name: "closes release pull request without commits",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				commit(t, f, "feat: add foo")
+				run()
+				// Released outside of releaser-pleaser
+				tagHead(t, f, "v0.1.0")
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				assert.Empty(t, f.OpenPullRequests())
+				require.Contains(t, f.PullRequests, int64(1))
+				assert.Equal(t, fake.PRStateClosed, f.PullRequests[1].State)
+			},
+		},
+		{
+			name: "creates release for merged pull request",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				require.NoError(t, f.MergePullRequest(1))
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				require.Contains(t, f.Releases, "v1.0.1")
+				release := f.Releases["v1.0.1"]
+				assert.Equal(t, f.PullRequests[1].ReleaseCommit.Hash, release.Commit.Hash)
+				assert.Contains(t, release.Changelog, "### Bug Fixes")
+				assert.Contains(t, release.Changelog, "bar")
+				assert.False(t, release.Prerelease)
+				assert.True(t, release.Latest)
+
+				assert.Equal(t, []releasepr.Label{releasepr.LabelReleaseTagged}, f.PullRequests[1].Labels)
+				// The release commit is not releasable on its own
+				assert.Empty(t, f.OpenPullRequests())
+			},
+		},
+		{
+			name: "release of older line is not latest",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.9.2")
+				commit(t, f, "fix: bar")
+				run()
+
+				// Released from another branch in the meantime
+				hash, err := f.Remote.Commit("release/2.x", "feat: new major", nil)
+				require.NoError(t, err)
+				require.NoError(t, f.Remote.Tag("v2.1.0", hash))
+
+				require.NoError(t, f.MergePullRequest(1))
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				require.Contains(t, f.Releases, "v1.9.3")
+				assert.False(t, f.Releases["v1.9.3"].Latest)
+				assert.False(t, f.Releases["v1.9.3"].Prerelease)
+			},
+		},
+		{
+			name: "prerelease changelog only contains changes since last prerelease",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "feat: first")
+				run()
+				f.PullRequests[1].Labels = append(f.PullRequests[1].Labels, releasepr.LabelNextVersionTypeRC)
+				run()
+				require.NoError(t, f.MergePullRequest(1))
+				run()
+				require.Contains(t, f.Releases, "v1.1.0-rc.0")
+				require.True(t, f.Releases["v1.1.0-rc.0"].Prerelease)
+				require.False(t, f.Releases["v1.1.0-rc.0"].Latest)
+
+				commit(t, f, "feat: second")
+				run()
+				f.PullRequests[2].Labels = append(f.PullRequests[2].Labels, releasepr.LabelNextVersionTypeRC)
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v1.1.0-rc.1", prs[0].Title)
+				assert.Contains(t, prs[0].Description, "second")
+				assert.NotContains(t, prs[0].Description, "first")
+			},
+		},
+		{
+			name: "stable release changelog contains changes since last stable release",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "feat: first")
+				tagHead(t, f, "v1.1.0-rc.0")
+				commit(t, f, "feat: second")
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v1.1.0", prs[0].Title)
+				assert.Contains(t, prs[0].Description, "first")
+				assert.Contains(t, prs[0].Description, "second")
+			},
+		},
+		{
+			name: "forces version from release pull request",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				require.NoError(t, f.PullRequests[1].SetDescription("", "", releasepr.ReleaseOverrides{Version: "v2.0.0"}))
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v2.0.0", prs[0].Title)
+				assert.Contains(t, prs[0].Description, "bar")
+
+				overrides, err := prs[0].GetOverrides()
+				require.NoError(t, err)
+				assert.Equal(t, "v2.0.0", overrides.Version)
+			},
+		},
+		{
+			name: "rejects forced version lower than latest release",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				require.NoError(t, f.PullRequests[1].SetDescription("", "", releasepr.ReleaseOverrides{Version: "0.9.0"}))
+			},
+			wantErr: assert.Error,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v1.0.1", prs[0].Title)
+			},
+		},
+		{
+			name: "forces version from commit footer",
+			setup: func(t *testing.T, f *fake.Forge, _ func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "chore: prepare stable release\n\nRelease-As: 2.0.0")
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v2.0.0", prs[0].Title)
+			},
+		},
+		{
+			name: "retries after conflict",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				commit(t, f, "feat: add foo")
+				run()
+				commit(t, f, "fix: bar")
+
+				f.OnPullRequestByID = func(pr *fake.PullRequest) {
+					// The user adds a prefix while releaser-pleaser is running
+					f.OnPullRequestByID = nil
+					require.NoError(t, pr.SetDescription("", "", releasepr.ReleaseOverrides{Prefix: "Hello from the user"}))
+				}
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Contains(t, prs[0].Description, "Hello from the user")
+				assert.Contains(t, prs[0].Description, "bar")
+			},
+		},
+		{
+			name: "fails after repeated conflicts",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				commit(t, f, "feat: add foo")
+				run()
+				commit(t, f, "fix: bar")
+
+				edits := 0
+				f.OnPullRequestByID = func(pr *fake.PullRequest) {
+					edits++
+					pr.Description += fmt.Sprintf("\nedit %d", edits)
+				}
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrorPullRequestConflict)
+			},
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.NotContains(t, prs[0].Description, "bar")
+			},
+		},
+		{
+			name: "dry run does not open release pull request",
+			setup: func(t *testing.T, f *fake.Forge, _ func()) {
+				commit(t, f, "feat: add foo")
+			},
+			dryRun:     true,
+			wantOutput: []string{"CHANGELOG.md", "+## [v0.1.0]"},
+			wantErr:    assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				assert.Empty(t, f.PullRequests)
+				assert.Empty(t, f.Labels)
+				assert.Empty(t, f.Releases)
+
+				_, err := f.Remote.Head("releaser-pleaser--branches--main")
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "dry run does not update release pull request",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				commit(t, f, "feat: add foo")
+			},
+			dryRun:     true,
+			wantOutput: []string{"+## [v1.1.0]"},
+			wantErr:    assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				prs := f.OpenPullRequests()
+				require.Len(t, prs, 1)
+				assert.Equal(t, "chore(main): release v1.0.1", prs[0].Title)
+				assert.NotContains(t, prs[0].Description, "add foo")
+
+				changelog, err := f.Remote.File(prs[0].Head, "CHANGELOG.md")
+				require.NoError(t, err)
+				assert.NotContains(t, string(changelog), "v1.1.0")
+			},
+		},
+		{
+			name: "dry run does not create release",
+			setup: func(t *testing.T, f *fake.Forge, run func()) {
+				_, err := f.Remote.Commit("main", "chore: init", map[string]string{"gradle.properties": "version=1.0.1-SNAPSHOT\n"})
+				require.NoError(t, err)
+				tagHead(t, f, "v1.0.0")
+				commit(t, f, "fix: bar")
+				run()
+				require.NoError(t, f.MergePullRequest(1))
+			},
+			dryRun:  true,
+			wantErr: assert.NoError,
+			check: func(t *testing.T, f *fake.Forge) {
+				assert.Empty(t, f.Releases)
+				assert.Equal(t, []releasepr.Label{releasepr.LabelReleasePending}, f.PullRequests[1].Labels)
+
+				content, err := f.Remote.File("main", "gradle.properties")
+				require.NoError(t, err)
+				assert.Equal(t, "version=1.0.1\n", string(content))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newTestForge(t)
+			rp := newTestReleaserPleaser(t, f)
+			if tt.dryRun {
+				rp.components[0].Updaters = append(rp.components[0].Updaters, updater.Gradle(true))
+			}
+
+			tt.setup(t, f, func() {
+				t.Helper()
+				runReleaserPleaser(t, rp)
+			})
+
+			var output bytes.Buffer
+			if tt.dryRun {
+				rp.forge = dryrun.New(rp.logger, f)
+				rp.dryRun = true
+				rp.output = &output
+				rp.components[0].AfterReleaseHooks = []string{"exit 1"}
+			}
+
+			result, err := rp.Run(ctx)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.dryRun, result.DryRun)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, output.String(), want)
+			}
+			tt.check(t, f)
+		})
+	}
+}
+
+func TestReleaserPleaser_RunResult(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "feat: foo")
+
+	result := runReleaserPleaser(t, rp)
+	assert.Equal(t, &RunResult{
+		Changed:  true,
+		Releases: []ReleaseResult{},
+		PullRequests: []PullRequestResult{{
+			ID:      1,
+			URL:     f.PullRequestURL(1),
+			Version: "1.1.0",
+			Tag:     "v1.1.0",
+			Status:  PullRequestCreated,
+		}},
+	}, result)
+
+	result = runReleaserPleaser(t, rp)
+	assert.False(t, result.Changed)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, PullRequestUnchanged, result.PullRequests[0].Status)
+
+	commit(t, f, "fix: bar")
+	result = runReleaserPleaser(t, rp)
+	assert.True(t, result.Changed)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, PullRequestUpdated, result.PullRequests[0].Status)
+
+	require.NoError(t, f.MergePullRequest(1))
+	result = runReleaserPleaser(t, rp)
+	assert.Equal(t, &RunResult{
+		Changed: true,
+		Releases: []ReleaseResult{{
+			Version: "1.1.0",
+			Tag:     "v1.1.0",
+			Commit:  f.PullRequests[1].ReleaseCommit.Hash,
+			URL:     f.ReleaseURL("v1.1.0"),
+			Latest:  true,
+		}},
+		PullRequests: []PullRequestResult{},
+	}, result)
+}
+
+func TestReleaserPleaser_RunWithNewLabels(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+
+	commit(t, f, "feat: foo")
+	runReleaserPleaser(t, rp)
+
+	// The forge only returns the name of the label, it is still recognized as existing in the next run
+	label := releasepr.Label{Name: "release", Color: "00FF00", Description: "Released with releaser-pleaser"}
+	rp.labels = []releasepr.Label{label}
+	result := runReleaserPleaser(t, rp)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, PullRequestUpdated, result.PullRequests[0].Status)
+	assert.Equal(t, []releasepr.Label{releasepr.LabelReleasePending, label}, f.PullRequests[1].Labels)
+
+	result = runReleaserPleaser(t, rp)
+	assert.False(t, result.Changed)
+	assert.Equal(t, PullRequestUnchanged, result.PullRequests[0].Status)
+}
+
+func TestReleaserPleaser_RunWithTemplates(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+
+	var err error
+	rp.templates.PullRequest, err = changelog.Parse("pull-request.md.tpl", `Bump: {{ .Data.Bump }}, previous: {{ .Data.PreviousVersion }}`)
+	require.NoError(t, err)
+	rp.templates.Release, err = changelog.Parse("release.md.tpl", `{{ range .Data.Sections }}{{ range .Commits }}{{ template "entry" . }}{{ end }}{{ end }}Released on {{ date "2006" .Data.ReleaseDate }}`)
+	require.NoError(t, err)
+
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "feat: add foo")
+	runReleaserPleaser(t, rp)
+
+	prs := f.OpenPullRequests()
+	require.Len(t, prs, 1)
+	assert.Contains(t, prs[0].Description, "Bump: minor, previous: v1.0.0")
+	assert.NotContains(t, prs[0].Description, "### Features")
+
+	require.NoError(t, f.MergePullRequest(prs[0].ID))
+	runReleaserPleaser(t, rp)
+
+	require.Contains(t, f.Releases, "v1.1.0")
+	release := f.Releases["v1.1.0"]
+	assert.Contains(t, release.Changelog, "add foo")
+	assert.Contains(t, release.Changelog, fmt.Sprintf("Released on %d", time.Now().UTC().Year()))
+	assert.NotContains(t, release.Changelog, "Bump: minor")
+
+	t.Run("pre-major bumps", func(t *testing.T) {
+		f := newTestForge(t)
+		rp := newTestReleaserPleaser(t, f)
+		rp.versioning = versioning.SemVerPreMajor
+		rp.templates.PullRequest, err = changelog.Parse("pull-request.md.tpl", `Bump: {{ .Data.Bump }}`)
+		require.NoError(t, err)
+
+		tagHead(t, f, "v0.3.0")
+		commit(t, f, "feat!: remove foo")
+		result := runReleaserPleaser(t, rp)
+
+		require.Len(t, result.PullRequests, 1)
+		assert.Equal(t, "v0.4.0", result.PullRequests[0].Tag)
+		prs := f.OpenPullRequests()
+		require.Len(t, prs, 1)
+		assert.Contains(t, prs[0].Description, "Bump: minor")
+	})
+}
+
+func TestReleaserPleaser_RunWithTagTemplate(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+	rp.components[0].TagTemplate = newTestTagTemplate(t, "release-{{.Version}}", "")
+
+	tagHead(t, f, "release-1.1.0")
+	commit(t, f, "fix: old tag format")
+	// Does not match the template and is ignored
+	tagHead(t, f, "v2.0.0")
+	commit(t, f, "feat: add foo")
+	runReleaserPleaser(t, rp)
+
+	prs := f.OpenPullRequests()
+	require.Len(t, prs, 1)
+	assert.Equal(t, "chore(main): release release-1.2.0", prs[0].Title)
+
+	require.NoError(t, f.MergePullRequest(prs[0].ID))
+	runReleaserPleaser(t, rp)
+
+	require.Contains(t, f.Releases, "release-1.2.0")
+	assert.False(t, f.Releases["release-1.2.0"].Prerelease)
+}
+
+func TestReleaserPleaser_RunWithOutdatedCalVer(t *testing.T) {
+	ctx := context.Background()
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+	strategy, err := versioning.NewCalVer("YYYY.0M.MICRO")
+	require.NoError(t, err)
+	rp.versioning = strategy
+	rp.components[0].TagTemplate = newTestTagTemplate(t, versioning.DefaultCalVerTagTemplate, "")
+
+	_, err = f.Remote.Commit("main", "chore: init", map[string]string{"CHANGELOG.md": "# Changelog\n\n## 2020.01.0\n\n- init\n"})
+	require.NoError(t, err)
+	tagHead(t, f, "2020.01.0")
+	commit(t, f, "fix: bar")
+
+	// A release pull request that was last updated in an earlier month and is merged now
+	_, err = f.Remote.Commit("releaser-pleaser--branches--main", "chore(main): release 2020.01.1", map[string]string{
+		"CHANGELOG.md": "# Changelog\n\n## 2020.01.1\n\n- bar\n\n## 2020.01.0\n\n- init\n",
+		"VERSION":      "2020.01.1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, rp.EnsureLabels(ctx))
+	pr, err := releasepr.NewReleasePullRequest("releaser-pleaser--branches--main", "main", "2020.01.1", "### Bug Fixes\n\n- bar (2020.01.1)\n", "")
+	require.NoError(t, err)
+	require.NoError(t, f.CreatePullRequest(ctx, pr))
+	require.NoError(t, f.MergePullRequest(pr.ID))
+	merged, err := f.Remote.Head("main")
+	require.NoError(t, err)
+
+	want := time.Now().UTC().Format("2006.01") + ".0"
+	result := runReleaserPleaser(t, rp)
+	require.Len(t, result.Releases, 1)
+	assert.Equal(t, want, result.Releases[0].Tag)
+	assert.NotEqual(t, merged, result.Releases[0].Commit)
+
+	require.Contains(t, f.Releases, want)
+	assert.Equal(t, result.Releases[0].Commit, f.Releases[want].Commit.Hash)
+	assert.Contains(t, f.Releases[want].Changelog, "- bar ("+want+")")
+
+	head, err := f.Remote.Head("main")
+	require.NoError(t, err)
+	assert.Equal(t, result.Releases[0].Commit, head)
+	content, err := f.Remote.File("main", "CHANGELOG.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## "+want+"\n\n- bar\n\n## 2020.01.0\n\n- init\n", string(content))
+	content, err = f.Remote.File("main", "VERSION")
+	require.NoError(t, err)
+	assert.Equal(t, want, string(content))
+
+	assert.Empty(t, f.OpenPullRequests())
+}
+
+func Test_replaceVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "version", text: "1.2.3", want: "2.0.0"},
+		{name: "tag", text: "## [v1.2.3](https://example.com/v1.2.3)", want: "## [v2.0.0](https://example.com/v2.0.0)"},
+		{name: "end of sentence", text: "Released 1.2.3.", want: "Released 2.0.0."},
+		{name: "adjacent", text: "1.2.3,1.2.3", want: "2.0.0,2.0.0"},
+		{name: "longer version", text: "1.2.30 and 11.2.3", want: "1.2.30 and 11.2.3"},
+		{name: "pre-release", text: "1.2.3-rc.1", want: "1.2.3-rc.1"},
+		{name: "more segments", text: "1.2.3.4", want: "1.2.3.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replaceVersion(tt.text, "1.2.3", "2.0.0"))
+		})
+	}
+}
+
+func TestReleaserPleaser_RunWithHooks(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+
+	released := filepath.Join(t.TempDir(), "released.txt")
+	rp.components[0].BeforeCommitHooks = []string{
+		`printf '%s %s' "$RP_VERSION" "$RP_TAG" > VERSION`,
+		"rm README.md",
+	}
+	rp.components[0].AfterReleaseHooks = []string{
+		fmt.Sprintf(`printf '%%s %%s' "$RP_TAG" "$RP_RELEASE_URL" > %q`, released),
+	}
+
+	_, err := f.Remote.Commit("main", "chore: init", map[string]string{"README.md": "# foo"})
+	require.NoError(t, err)
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "fix: bar")
+	runReleaserPleaser(t, rp)
+
+	version, err := f.Remote.File("releaser-pleaser--branches--main", "VERSION")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1 v1.0.1", string(version))
+	readme, err := f.Remote.File("releaser-pleaser--branches--main", "README.md")
+	require.NoError(t, err)
+	assert.Nil(t, readme)
+
+	require.NoError(t, f.MergePullRequest(1))
+	runReleaserPleaser(t, rp)
+
+	content, err := os.ReadFile(released)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.1 "+f.ReleaseURL("v1.0.1"), string(content))
+
+	t.Run("fails on hook error", func(t *testing.T) {
+		rp.components[0].BeforeCommitHooks = []string{"exit 1"}
+
+		commit(t, f, "fix: baz")
+		_, err := rp.Run(context.Background())
+		assert.ErrorContains(t, err, `hook "exit 1" failed`)
+	})
+}
+
+func TestReleaserPleaser_RunWithPlainGitTagMode(t *testing.T) {
+	f := newTestForge(t)
+
+	p, err := plaingit.New(slog.New(slog.DiscardHandler), &plaingit.Options{
+		Options: forge.Options{BaseBranch: "main"},
+		URL:     f.Remote.URL(),
+		Mode:    plaingit.ModeTag,
+	})
+	require.NoError(t, err)
+
+	rp := newTestReleaserPleaser(t, p)
+	rp.components[0].Updaters = append(rp.components[0].Updaters, updater.Gradle(true))
+	released := filepath.Join(t.TempDir(), "released.txt")
+	rp.components[0].AfterReleaseHooks = []string{fmt.Sprintf(`printf '%%s' "$RP_TAG" > %q`, released)}
+
+	_, err = f.Remote.Commit("main", "chore: init", map[string]string{"gradle.properties": "version=1.0.0\n"})
+	require.NoError(t, err)
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "feat: foo")
+
+	result := runReleaserPleaser(t, rp)
+	head, err := f.Remote.Head("main")
+	require.NoError(t, err)
+	assert.Equal(t, []PullRequestResult{}, result.PullRequests)
+	require.Len(t, result.Releases, 1)
+	assert.Equal(t, "v1.1.0", result.Releases[0].Tag)
+
+	ref, err := f.Remote.Repository().Tag("v1.1.0")
+	require.NoError(t, err)
+	tag, err := f.Remote.Repository().TagObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, result.Releases[0].Commit, tag.Target.String())
+	assert.Contains(t, tag.Message, "### Features")
+
+	content, err := os.ReadFile(released)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0", string(content))
+
+	// The next snapshot version is committed on top of the release commit
+	assert.NotEqual(t, result.Releases[0].Commit, head)
+	properties, err := f.Remote.File("main", "gradle.properties")
+	require.NoError(t, err)
+	assert.Equal(t, "version=1.1.1-SNAPSHOT\n", string(properties))
+
+	result = runReleaserPleaser(t, rp)
+	assert.False(t, result.Changed)
+}
+
+// TestReleaserPleaser_RunWithUpdaters checks the files of the release pull request for updaters that find or skip files
+// on their own.
+func TestReleaserPleaser_RunWithPlainGitBranchMode(t *testing.T) {
+	f := newTestForge(t)
+
+	newPlainGit := func() *plaingit.PlainGit {
+		p, err := plaingit.New(slog.New(slog.DiscardHandler), &plaingit.Options{
+			Options: forge.Options{BaseBranch: "main"},
+			URL:     f.Remote.URL(),
+			Mode:    plaingit.ModeBranch,
+		})
+		require.NoError(t, err)
+
+		return p
+	}
+
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "feat: foo")
+
+	result := runReleaserPleaser(t, newTestReleaserPleaser(t, newPlainGit()))
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, PullRequestCreated, result.PullRequests[0].Status)
+
+	// The existing release branch is found in the next run
+	result = runReleaserPleaser(t, newTestReleaserPleaser(t, newPlainGit()))
+	assert.False(t, result.Changed)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, PullRequestUnchanged, result.PullRequests[0].Status)
+
+	// Merged by the user, the tag is created in a later run with the changelog from the release commit
+	head, err := f.Remote.Head("releaser-pleaser--branches--main")
+	require.NoError(t, err)
+	message, err := f.Remote.Repository().CommitObject(plumbing.NewHash(head))
+	require.NoError(t, err)
+	_, err = f.Remote.SquashMerge("main", "releaser-pleaser--branches--main", message.Message)
+	require.NoError(t, err)
+
+	result = runReleaserPleaser(t, newTestReleaserPleaser(t, newPlainGit()))
+	require.Len(t, result.Releases, 1)
+	assert.Equal(t, "v1.1.0", result.Releases[0].Tag)
+
+	ref, err := f.Remote.Repository().Tag("v1.1.0")
+	require.NoError(t, err)
+	tag, err := f.Remote.Repository().TagObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Contains(t, tag.Message, "- foo")
+
+	// The release branch is deleted, as there are no new commits
+	_, err = f.Remote.Head("releaser-pleaser--branches--main")
+	assert.Error(t, err)
+}
+
+func TestReleaserPleaser_RunWithUpdaters(t *testing.T) {
+	tests := []struct {
+		name     string
+		updaters []updater.Updater
+		files    map[string]string
+		// want is the content of the files on the release branch after "feat: bar" was released on top of v1.0.0.
+		want map[string]string
+	}{
+		{
+			name:     "cargo workspace",
+			updaters: []updater.Updater{updater.Cargo()},
+			files: map[string]string{
+				"Cargo.toml":            "[workspace]\nmembers = [\"crates/*\"]\n\n[workspace.package]\nversion = \"1.0.0\"\n",
+				"Cargo.lock":            "version = 4\n\n[[package]]\nname = \"foo\"\nversion = \"1.0.0\"\n",
+				"crates/foo/Cargo.toml": "[package]\nname = \"foo\"\nversion.workspace = true\n",
+				"crates/cli/Cargo.toml": "[package]\nname = \"foo-cli\"\nversion = \"1.0.0\"\n\n[dependencies]\nfoo = { path = \"../foo\", version = \"1.0.0\" }\n",
+			},
+			want: map[string]string{
+				"Cargo.toml":            "[workspace]\nmembers = [\"crates/*\"]\n\n[workspace.package]\nversion = \"1.1.0\"\n",
+				"Cargo.lock":            "version = 4\n\n[[package]]\nname = \"foo\"\nversion = \"1.1.0\"\n",
+				"crates/foo/Cargo.toml": "[package]\nname = \"foo\"\nversion.workspace = true\n",
+				"crates/cli/Cargo.toml": "[package]\nname = \"foo-cli\"\nversion = \"1.1.0\"\n\n[dependencies]\nfoo = { path = \"../foo\", version = \"1.1.0\" }\n",
+			},
+		},
+		{
+			name:     "generic scan",
+			updaters: []updater.Updater{updater.GenericScan(nil, []string{"vendor"})},
+			files: map[string]string{
+				"docs/install.md":    "Install v1.0.0 <!-- x-releaser-pleaser-version -->\n",
+				"docs/index.md":      "Current version: v1.0.0\n",
+				"version/version.go": "const Version = \"v1.0.0\" // x-releaser-pleaser-version\n",
+				"vendor/foo/foo.go":  "const Version = \"v1.0.0\" // x-releaser-pleaser-version\n",
+			},
+			want: map[string]string{
+				"docs/install.md":    "Install v1.1.0 <!-- x-releaser-pleaser-version -->\n",
+				"docs/index.md":      "Current version: v1.0.0\n",
+				"version/version.go": "const Version = \"v1.1.0\" // x-releaser-pleaser-version\n",
+				"vendor/foo/foo.go":  "const Version = \"v1.0.0\" // x-releaser-pleaser-version\n",
+			},
+		},
+		{
+			name:     "pyproject with init version",
+			updaters: []updater.Updater{updater.Pyproject(true)},
+			files: map[string]string{
+				"pyproject.toml":      "[project]\nname = \"foo\"\nversion = \"1.0.0\"\n",
+				"src/foo/__init__.py": "__version__ = \"1.0.0\"\n",
+			},
+			want: map[string]string{
+				"pyproject.toml":      "[project]\nname = \"foo\"\nversion = \"1.1.0\"\n",
+				"src/foo/__init__.py": "__version__ = \"1.1.0\"\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestForge(t)
+			rp := newTestReleaserPleaser(t, f)
+			rp.components[0].Updaters = tt.updaters
+
+			_, err := f.Remote.Commit("main", "chore: init", tt.files)
+			require.NoError(t, err)
+			tagHead(t, f, "v1.0.0")
+			commit(t, f, "feat: bar")
+			runReleaserPleaser(t, rp)
+
+			for file, want := range tt.want {
+				content, err := f.Remote.File("releaser-pleaser--branches--main", file)
+				require.NoError(t, err)
+				assert.Equal(t, want, string(content), file)
+			}
+		})
+	}
+}
+
+func TestReleaserPleaser_RunWithSnapshot(t *testing.T) {
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+	rp.components[0].Updaters = []updater.Updater{updater.Gradle(true)}
+
+	_, err := f.Remote.Commit("main", "chore: init", map[string]string{"gradle.properties": "version=1.0.1-SNAPSHOT\n"})
+	require.NoError(t, err)
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "fix: bar")
+	runReleaserPleaser(t, rp)
+
+	content, err := f.Remote.File("releaser-pleaser--branches--main", "gradle.properties")
+	require.NoError(t, err)
+	assert.Equal(t, "version=1.0.1\n", string(content))
+
+	require.NoError(t, f.MergePullRequest(1))
+	result := runReleaserPleaser(t, rp)
+	require.Len(t, result.Releases, 1)
+	assert.Equal(t, "v1.0.1", result.Releases[0].Tag)
+
+	content, err = f.Remote.File("main", "gradle.properties")
+	require.NoError(t, err)
+	assert.Equal(t, "version=1.0.2-SNAPSHOT\n", string(content))
+
+	// The snapshot commit is not releasable
+	assert.Empty(t, f.OpenPullRequests())
+
+	t.Run("protected target branch", func(t *testing.T) {
+		f.Remote.Protect("main")
+
+		commit(t, f, "fix: baz")
+		runReleaserPleaser(t, rp)
+		require.NoError(t, f.MergePullRequest(2))
+		head, err := f.Remote.Head("main")
+		require.NoError(t, err)
+
+		// The rejected push does not fail the run
+		result := runReleaserPleaser(t, rp)
+		require.Len(t, result.Releases, 1)
+		assert.Equal(t, "v1.0.2", result.Releases[0].Tag)
+		assert.Contains(t, f.Releases, "v1.0.2")
+
+		got, err := f.Remote.Head("main")
+		require.NoError(t, err)
+		assert.Equal(t, head, got)
+		content, err := f.Remote.File("main", "gradle.properties")
+		require.NoError(t, err)
+		assert.Equal(t, "version=1.0.2\n", string(content))
+	})
+}
+
+func TestReleaserPleaser_RunWithAssets(t *testing.T) {
+	ctx := context.Background()
+	f := newTestForge(t)
+	rp := newTestReleaserPleaser(t, f)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.tar.gz"), []byte("foo\n"), 0o644))
+	rp.components[0].Assets = []string{filepath.Join(dir, "*.tar.gz")}
+
+	tagHead(t, f, "v1.0.0")
+	commit(t, f, "fix: bar")
+	runReleaserPleaser(t, rp)
+	require.NoError(t, f.MergePullRequest(1))
+	runReleaserPleaser(t, rp)
+
+	require.Contains(t, f.Releases, "v1.0.1")
+	assert.Equal(t, map[string][]byte{
+		"app.tar.gz":    []byte("foo\n"),
+		"checksums.txt": []byte("b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c  app.tar.gz\n"),
+	}, f.Releases["v1.0.1"].Assets)
+
+	t.Run("keeps release pending if assets are missing", func(t *testing.T) {
+		rp.components[0].Assets = []string{filepath.Join(dir, "*.zip")}
+
+		commit(t, f, "fix: baz")
+		runReleaserPleaser(t, rp)
+		prs := f.OpenPullRequests()
+		require.Len(t, prs, 1)
+		require.NoError(t, f.MergePullRequest(prs[0].ID))
+
+		_, err := rp.Run(ctx)
+		assert.ErrorContains(t, err, "does not match any files")
+		assert.NotContains(t, f.Releases, "v1.0.2")
+		assert.Contains(t, f.PullRequests[prs[0].ID].Labels, releasepr.LabelReleasePending)
+	})
+}
+
+func Test_forcedVersion(t *testing.T) {
+	component := Component{TagTemplate: newTestTagTemplate(t, versioning.DefaultSemVerTagTemplate, "")}
+	latest := git.Releases{Latest: &git.Tag{Name: "1.1.0-rc.0"}, Stable: &git.Tag{Name: "1.0.0"}}
+	rc := releasepr.ReleaseOverrides{NextVersionType: versioning.NextVersionTypeRC}
+
+	tests := []struct {
+		name      string
+		releases  git.Releases
+		overrides releasepr.ReleaseOverrides
+		commits   []commitparser.AnalyzedCommit
+		want      string
+		// wantWarning is logged if it is not empty
+		wantWarning string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "nothing forced",
+			releases: latest,
+			commits:  []commitparser.AnalyzedCommit{{Type: "feat"}},
+			want:     "",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:      "override",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{Version: "2.0.0"},
+			want:      "2.0.0",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "override as tag",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{Version: "v2.0.0"},
+			want:      "2.0.0",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "override without releases",
+			overrides: releasepr.ReleaseOverrides{Version: "0.5.0"},
+			want:      "0.5.0",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "override takes precedence over footers",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{Version: "1.5.0"},
+			commits:   []commitparser.AnalyzedCommit{{ReleaseAs: "3.0.0"}},
+			want:      "1.5.0",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "override not greater than latest release",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{Version: "1.1.0-rc.0"},
+			wantErr:   assert.Error,
+		},
+		{
+			name:      "invalid override",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{Version: "next"},
+			wantErr:   assert.Error,
+		},
+		{
+			name:     "highest footer",
+			releases: latest,
+			commits: []commitparser.AnalyzedCommit{
+				{ReleaseAs: "2.0.0"},
+				{ReleaseAs: "v3.0.0"},
+				{ReleaseAs: "2.5.0"},
+			},
+			want:    "3.0.0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "ignores footer of latest release",
+			releases: latest,
+			commits:  []commitparser.AnalyzedCommit{{ReleaseAs: "1.1.0-rc.0"}},
+			want:     "",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:        "warns about footer lower than latest release",
+			releases:    latest,
+			commits:     []commitparser.AnalyzedCommit{{Commit: git.Commit{Hash: "abc"}, ReleaseAs: "0.9.0"}},
+			want:        "",
+			wantWarning: "ignoring Release-As footer",
+			wantErr:     assert.NoError,
+		},
+		{
+			name:     "invalid footer",
+			releases: latest,
+			commits:  []commitparser.AnalyzedCommit{{Commit: git.Commit{Hash: "abc"}, ReleaseAs: "next"}},
+			wantErr:  assert.Error,
+		},
+		{
+			name:      "pre-release override",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{NextVersionType: versioning.NextVersionTypeRC, Version: "2.0.0-rc.0"},
+			want:      "2.0.0-rc.0",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "stable override for pre-release",
+			releases:  latest,
+			overrides: releasepr.ReleaseOverrides{NextVersionType: versioning.NextVersionTypeRC, Version: "2.0.0"},
+			wantErr:   assert.Error,
+		},
+		{
+			name:      "stable footer for pre-release",
+			releases:  latest,
+			overrides: rc,
+			commits:   []commitparser.AnalyzedCommit{{ReleaseAs: "2.0.0"}},
+			wantErr:   assert.Error,
+		},
+		{
+			name:      "pre-release without forced version",
+			releases:  latest,
+			overrides: rc,
+			commits:   []commitparser.AnalyzedCommit{{Type: "feat"}},
+			want:      "",
+			wantErr:   assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+			got, err := forcedVersion(logger, versioning.SemVer, component, tt.releases, tt.overrides, tt.commits)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			if tt.wantWarning != "" {
+				assert.Contains(t, logs.String(), "level=WARN")
+				assert.Contains(t, logs.String(), tt.wantWarning)
+			} else {
+				assert.Empty(t, logs.String())
+			}
+		})
+	}
+}
+
+func TestReleaserPleaser_RunOnMaintenanceBranch(t *testing.T) {
+	const branch = "release/1.x"
+
+	setup := func(t *testing.T) (*fake.Forge, *ReleaserPleaser) {
+		t.Helper()
+
+		f, err := fake.New(slog.New(slog.DiscardHandler), &fake.Options{
+			Options: forge.Options{
+				Repository: fmt.Sprintf("test-%d", testRemoteID.Add(1)),
+				BaseBranch: branch,
+			},
+		})
+		require.NoError(t, err)
+		t.Cleanup(f.Remote.Close)
+
+		hash, err := f.Remote.Commit(branch, "feat: foo", nil)
+		require.NoError(t, err)
+		require.NoError(t, f.Remote.Tag("v1.0.0", hash))
+
+		// The next major version is developed and released on main
+		hash, err = f.Remote.Commit("main", "feat!: bar", nil)
+		require.NoError(t, err)
+		require.NoError(t, f.Remote.Tag("v2.0.0", hash))
+
+		rp := newTestReleaserPleaser(t, f)
+		rp.targetBranch = branch
+		rp.versionLine = versioning.VersionLineFromBranch(branch)
+
+		return f, rp
+	}
+
+	t.Run("ignores tags of other branches", func(t *testing.T) {
+		f, rp := setup(t)
+
+		_, err := f.Remote.Commit(branch, "fix: backport", nil)
+		require.NoError(t, err)
+		runReleaserPleaser(t, rp)
+
+		prs := f.OpenPullRequests()
+		require.Len(t, prs, 1)
+		assert.Equal(t, "chore(release/1.x): release v1.0.1", prs[0].Title)
+	})
+
+	t.Run("fails for major bump", func(t *testing.T) {
+		f, rp := setup(t)
+
+		_, err := f.Remote.Commit(branch, "feat!: breaking backport", nil)
+		require.NoError(t, err)
+
+		_, err = rp.Run(context.Background())
+		require.ErrorContains(t, err, "not part of the version line 1.x")
+		require.ErrorContains(t, err, "major version bump")
+		assert.Empty(t, f.OpenPullRequests())
+	})
+}
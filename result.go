@@ -0,0 +1,54 @@
+package rp
+
+import (
+	"slices"
+)
+
+const (
+	PullRequestCreated   = "created"
+	PullRequestUpdated   = "updated"
+	PullRequestUnchanged = "unchanged"
+	PullRequestClosed    = "closed"
+)
+
+// RunResult describes the changes made by ReleaserPleaser.Run. It is serialized as JSON for other tools.
+type RunResult struct {
+	// Changed is true if any release was created or any release pull request was created, updated or closed.
+	Changed bool `json:"changed"`
+	// DryRun is true if the run did not apply the changes. The result describes the changes that would have been made.
+	DryRun bool `json:"dry_run"`
+
+	Releases     []ReleaseResult     `json:"releases"`
+	PullRequests []PullRequestResult `json:"pull_requests"`
+}
+
+// ReleaseResult is a release that was created for a merged release pull request.
+type ReleaseResult struct {
+	// Component is the name of the component, it is empty for the root component.
+	Component  string `json:"component"`
+	Version    string `json:"version"`
+	Tag        string `json:"tag"`
+	Commit     string `json:"commit"`
+	URL        string `json:"url"`
+	Prerelease bool   `json:"prerelease"`
+	Latest     bool   `json:"latest"`
+}
+
+// PullRequestResult is the release pull request of a component after the run.
+type PullRequestResult struct {
+	// Component is the name of the component, it is empty for the root component.
+	Component string `json:"component"`
+	ID        int64  `json:"id"`
+	URL       string `json:"url"`
+	// Version and Tag are the next release of the component. They are empty if the pull request was closed.
+	Version string `json:"version"`
+	Tag     string `json:"tag"`
+	// Status is one of PullRequestCreated, PullRequestUpdated, PullRequestUnchanged or PullRequestClosed.
+	Status string `json:"status"`
+}
+
+func (r *RunResult) updateChanged() {
+	r.Changed = len(r.Releases) > 0 || slices.ContainsFunc(r.PullRequests, func(pr PullRequestResult) bool {
+		return pr.Status != PullRequestUnchanged
+	})
+}